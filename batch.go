@@ -0,0 +1,98 @@
+package rut
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Result is the outcome of validating one input
+type Result struct {
+	// Input is the value as received
+	Input string
+
+	// Rut is the normalized value, empty when Err is not nil
+	Rut Rut
+
+	// ExpectedDV is the 'digito verificador' the 'cuerpo' requires,
+	// zero when the input is too malformed to compute it
+	ExpectedDV rune
+
+	// Err is one of the package errors, or the context error for
+	// the inputs left unprocessed after a cancellation
+	Err error
+}
+
+// Valid reports whether the input is a valid rut
+func (r Result) Valid() bool {
+	return r.Err == nil
+}
+
+// Check validates input and reports the outcome as a Result
+func Check(input string) (res Result) {
+	return check(input, MinRutlength, MaxRutlength)
+}
+
+// check is Check with the given length bounds
+func check(input string, minLen, maxLen int) (res Result) {
+	res.Input = input
+
+	rut := Rut(input)
+	info, err := rut.validate(minLen, maxLen)
+	if info != nil {
+		res.ExpectedDV = info.ExpectedDV
+	}
+	if res.Err = err; err == nil {
+		res.Rut = rut
+	}
+	return
+}
+
+// ValidateBatch validates every input across workers goroutines,
+// results are in the same order as the inputs.
+// workers <= 0 uses runtime.GOMAXPROCS(0).
+// once ctx is cancelled the pending inputs get ctx.Err() as their Err
+func ValidateBatch(ctx context.Context, in []string, workers int) []Result {
+	results := make([]Result, len(in))
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(in) {
+		workers = len(in)
+	}
+
+	var (
+		next int64
+		wg   sync.WaitGroup
+		done = ctx.Done()
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= len(in) {
+					return
+				}
+				results[i] = Check(in[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	// every claimed index was processed, the rest were left by a cancellation
+	for i := int(next); i < len(in); i++ {
+		results[i] = Result{Input: in[i], Err: ctx.Err()}
+	}
+
+	return results
+}
@@ -0,0 +1,53 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestValidateBatch(t *testing.T) {
+	in := make([]string, 1000)
+	for i := range in {
+		if i%3 == 0 {
+			in[i] = strconv.Itoa(10000000+i) + "-0"
+		} else {
+			in[i] = string(GenerateRut(5000000, 23000000))
+		}
+	}
+
+	results := ValidateBatch(context.Background(), in, 4)
+	if len(results) != len(in) {
+		t.Fatal("expected", len(in), "results, got", len(results))
+	}
+
+	for i, res := range results {
+		if res.Input != in[i] {
+			t.Fatal("order not preserved at", i)
+		}
+
+		_, err := Parse(in[i])
+		if res.Err != err {
+			t.Error(in[i], "expected", err, "got", res.Err)
+		}
+		if res.Valid() != (err == nil) {
+			t.Error(in[i], "unexpected Valid()")
+		}
+		if res.ExpectedDV == 0 {
+			t.Error(in[i], "missing expected dv")
+		}
+	}
+}
+
+func TestValidateBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := ValidateBatch(ctx, []string{"11111111-1", "12345678-5"}, 0)
+	for _, res := range results {
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Error(res.Input, "expected", context.Canceled, "got", res.Err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package rut
+
+import (
+	"iter"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/alvarolm/rut/internal/bitmap"
+)
+
+// bodyset is a set of 'cuerpos', the zero value is empty. the ones above
+// math.MaxUint32, which a larger MaxRutlength allows, are kept apart from
+// the bitmap
+type bodyset struct {
+	small bitmap.Bitmap
+	large map[uint64]struct{}
+}
+
+// set adds body, it returns false if it was already in the set
+func (s *bodyset) set(body uint64) bool {
+	if body <= math.MaxUint32 {
+		return s.small.Set(uint32(body))
+	}
+	if _, ok := s.large[body]; ok {
+		return false
+	}
+	if s.large == nil {
+		s.large = make(map[uint64]struct{})
+	}
+	s.large[body] = struct{}{}
+	return true
+}
+
+func (s *bodyset) has(body uint64) bool {
+	if body <= math.MaxUint32 {
+		return s.small.Has(uint32(body))
+	}
+	_, ok := s.large[body]
+	return ok
+}
+
+func (s *bodyset) len() int {
+	return s.small.Len() + len(s.large)
+}
+
+// all yields the 'cuerpos' in ascending order
+func (s *bodyset) all() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		stopped := false
+		s.small.Iterate(func(body uint32) bool {
+			stopped = !yield(uint64(body))
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+		for _, body := range slices.Sorted(maps.Keys(s.large)) {
+			if !yield(body) {
+				return
+			}
+		}
+	}
+}
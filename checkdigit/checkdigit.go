@@ -0,0 +1,153 @@
+/*
+Package checkdigit implements weighted modulus check digits, the family
+of algorithms behind the chilean 'digito verificador' and many other
+tax identifiers and document folios.
+
+the rut 'digito verificador' is RUT:
+
+	checkdigit.MustNew(11, []int{2, 3, 4, 5, 6, 7}, "0K987654321")
+
+weights are applied from the rightmost digit and cycled, symbols maps
+the remainder of the weighted sum to the check digit.
+*/
+package checkdigit
+
+import (
+	"errors"
+)
+
+var (
+	ErrModulus  = errors.New("modulus must be greater than 1")
+	ErrWeights  = errors.New("at least one weight is required")
+	ErrSymbols  = errors.New("expected one symbol per remainder")
+	ErrNotDigit = errors.New("expected digit, instead found invalid character")
+	ErrNoSymbol = errors.New("remainder has no check digit symbol")
+	ErrNoDigits = errors.New("at least one digit is required")
+	ErrNegative = errors.New("negative number")
+)
+
+// NoSymbol marks remainders without a valid check digit in the symbols
+const NoSymbol = 0
+
+// RUT is the chilean 'digito verificador' scheme
+var RUT = MustNew(11, []int{2, 3, 4, 5, 6, 7}, "0K987654321")
+
+// Scheme is a weighted modulus check digit algorithm,
+// it's immutable and safe for concurrent use
+type Scheme struct {
+	modulus int
+
+	// contributions[p][d] is the product of the digit d and the weight of
+	// the position p, positions are counted from the rightmost digit and
+	// repeat every len(weights) digits
+	contributions [][10]int
+
+	symbols string
+}
+
+// New returns a scheme for modulus and weights,
+// symbols[r] is the check digit when the weighted sum leaves the remainder r,
+// use NoSymbol for remainders that can't be issued
+func New(modulus int, weights []int, symbols string) (*Scheme, error) {
+	if modulus < 2 {
+		return nil, ErrModulus
+	}
+	if len(weights) == 0 {
+		return nil, ErrWeights
+	}
+	if len(symbols) != modulus {
+		return nil, ErrSymbols
+	}
+
+	s := &Scheme{
+		modulus:       modulus,
+		contributions: make([][10]int, len(weights)),
+		symbols:       symbols,
+	}
+	for p, weight := range weights {
+		for d := range s.contributions[p] {
+			// reduced, so sums of any realistic length can't overflow
+			s.contributions[p][d] = (d * weight) % modulus
+		}
+	}
+	return s, nil
+}
+
+// MustNew is like New but panics on invalid parameters,
+// meant for package level scheme declarations
+func MustNew(modulus int, weights []int, symbols string) *Scheme {
+	s, err := New(modulus, weights, symbols)
+	if err != nil {
+		panic("checkdigit: " + err.Error())
+	}
+	return s
+}
+
+// Compute returns the check digit of a string of decimal digits
+func (s *Scheme) Compute(digits string) (byte, error) {
+	if len(digits) == 0 {
+		return NoSymbol, ErrNoDigits
+	}
+
+	var sum, p int
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i] - '0'
+		if d > 9 {
+			return NoSymbol, ErrNotDigit
+		}
+		sum += s.contributions[p][d]
+		if p++; p == len(s.contributions) {
+			p = 0
+		}
+	}
+	return s.symbol(sum)
+}
+
+// ComputeInt returns the check digit of n without formatting it
+func (s *Scheme) ComputeInt(n int64) (byte, error) {
+	if n < 0 {
+		return NoSymbol, ErrNegative
+	}
+
+	var sum, p int
+	for ; n > 0; n /= 10 {
+		sum += s.contributions[p][n%10]
+		if p++; p == len(s.contributions) {
+			p = 0
+		}
+	}
+	return s.symbol(sum)
+}
+
+// Valid reports whether check is the check digit of digits
+func (s *Scheme) Valid(digits string, check byte) bool {
+	expected, err := s.Compute(digits)
+	return err == nil && expected == check
+}
+
+// Period returns the number of weights, the positions of Contribution
+// repeat every Period digits
+func (s *Scheme) Period() int {
+	return len(s.contributions)
+}
+
+// Contribution returns the term of the digit value d, 0 to 9, at the
+// position p of the weighted sum, p counts from the rightmost digit modulo
+// Period. it's the table lookup behind Compute, for the callers summing
+// the digits while they parse them
+func (s *Scheme) Contribution(p int, d byte) int {
+	return s.contributions[p][d]
+}
+
+// Symbol returns the check digit of a sum of Contributions
+func (s *Scheme) Symbol(sum int) (byte, error) {
+	return s.symbol(sum)
+}
+
+func (s *Scheme) symbol(sum int) (byte, error) {
+	symbol := s.symbols[sum%s.modulus]
+	if symbol == NoSymbol {
+		return NoSymbol, ErrNoSymbol
+	}
+	return symbol, nil
+}
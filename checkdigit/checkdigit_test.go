@@ -0,0 +1,87 @@
+package checkdigit
+
+import (
+	"strconv"
+	"testing"
+)
+
+var rut = RUT
+
+func TestCompute(t *testing.T) {
+	for digits, expected := range map[string]byte{
+		"11111111": '1',
+		"12345678": '5',
+		"13117182": 'K',
+		"0":        '0',
+	} {
+		got, err := rut.Compute(digits)
+		if err != nil {
+			t.Error(digits, err)
+		}
+		if got != expected {
+			t.Errorf("%s: expected %c, got %c", digits, expected, got)
+		}
+	}
+
+	if _, err := rut.Compute("1234a678"); err != ErrNotDigit {
+		t.Error("expected", ErrNotDigit, "got", err)
+	}
+	if _, err := rut.Compute(""); err != ErrNoDigits {
+		t.Error("expected", ErrNoDigits, "got", err)
+	}
+}
+
+func TestComputeInt(t *testing.T) {
+	for n := int64(0); n < 1000000; n += 7 {
+		expected, _ := rut.Compute(strconv.FormatInt(n, 10))
+		if got, _ := rut.ComputeInt(n); got != expected {
+			t.Fatalf("%d: expected %c, got %c", n, expected, got)
+		}
+	}
+
+	if _, err := rut.ComputeInt(-1); err != ErrNegative {
+		t.Error("expected", ErrNegative, "got", err)
+	}
+}
+
+func TestNoSymbol(t *testing.T) {
+	// remainder 10 can't be issued
+	s := MustNew(11, []int{2, 3, 4, 5, 6, 7}, "0\x00987654321")
+
+	if _, err := s.Compute("13117182"); err != ErrNoSymbol {
+		t.Error("expected", ErrNoSymbol, "got", err)
+	}
+	if s.Valid("13117182", NoSymbol) {
+		t.Error("NoSymbol reported as valid")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(1, []int{1}, "0"); err != ErrModulus {
+		t.Error("expected", ErrModulus, "got", err)
+	}
+	if _, err := New(10, nil, "0123456789"); err != ErrWeights {
+		t.Error("expected", ErrWeights, "got", err)
+	}
+	if _, err := New(10, []int{1}, "0"); err != ErrSymbols {
+		t.Error("expected", ErrSymbols, "got", err)
+	}
+}
+
+func TestContribution(t *testing.T) {
+	if rut.Period() != 6 {
+		t.Fatal("expected 6 weights, got", rut.Period())
+	}
+
+	// 12345678 summed from the rightmost digit
+	var sum, p int
+	for _, d := range []byte{8, 7, 6, 5, 4, 3, 2, 1} {
+		sum += rut.Contribution(p, d)
+		if p++; p == rut.Period() {
+			p = 0
+		}
+	}
+	if dv, err := rut.Symbol(sum); err != nil || dv != '5' {
+		t.Errorf("expected 5, got %c %v", dv, err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alvarolm/rut"
+)
+
+// benchInputs is the number of inputs the validation benchmark cycles through
+const benchInputs = 1024
+
+// benchFlags are the flags of bench
+type benchFlags struct {
+	cpu      *int
+	duration *time.Duration
+}
+
+func newBenchFlags(fs *flag.FlagSet) *benchFlags {
+	fs.Usage = usageFunc(fs, "usage: rut bench [-cpu n] [-duration d]")
+	return &benchFlags{
+		cpu:      fs.Int("cpu", runtime.GOMAXPROCS(0), "`number` of goroutines and GOMAXPROCS"),
+		duration: fs.Duration("duration", time.Second, "measuring `time` of every benchmark"),
+	}
+}
+
+// bench measures the validations and generations per second of the host
+func bench(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("bench", stderr)
+	f := newBenchFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 || *f.cpu < 1 || *f.duration <= 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(*f.cpu))
+
+	// valid ruts in the usual spellings with an invalid one every eight
+	g, _ := rut.NewGenerator(rut.GenerateOptions{Rand: rand.New(rand.NewSource(1))})
+	inputs := make([]string, benchInputs)
+	for i := range inputs {
+		r, _ := g.Next()
+		switch {
+		case i%8 == 7:
+			dv := "0"
+			if r[len(r)-1] == '0' {
+				dv = "1"
+			}
+			inputs[i] = string(r[:len(r)-1]) + dv
+		case i%2 == 1:
+			inputs[i], _ = r.Format(rut.FormatOptions{Style: rut.StyleDotted})
+		default:
+			inputs[i] = string(r)
+		}
+	}
+
+	validations := measure(*f.cpu, *f.duration, func(int) func(int) {
+		return func(i int) {
+			rut.Check(inputs[i%len(inputs)])
+		}
+	})
+	generations := measure(*f.cpu, *f.duration, func(worker int) func(int) {
+		g, _ := rut.NewGenerator(rut.GenerateOptions{Rand: rand.New(rand.NewSource(int64(worker) + 1))})
+		var buf []byte
+		return func(int) {
+			buf, _ = g.AppendNext(buf[:0], rut.FormatOptions{})
+		}
+	})
+
+	fmt.Fprintf(stdout, "go        %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(stdout, "cpu       %d\n", *f.cpu)
+	fmt.Fprintf(stdout, "validate  %.0f/s\n", validations)
+	fmt.Fprintf(stdout, "generate  %.0f/s\n", generations)
+	return exitOK
+}
+
+// measure runs the op of every worker goroutine for d and returns the
+// ops per second, newOp is called once per worker before the clock starts
+func measure(workers int, d time.Duration, newOp func(worker int) func(i int)) float64 {
+	ops := make([]func(int), workers)
+	for w := range ops {
+		ops[w] = newOp(w)
+	}
+
+	var (
+		stop  atomic.Bool
+		total atomic.Int64
+		wg    sync.WaitGroup
+	)
+	start := time.Now()
+	for _, op := range ops {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var n int
+			// the clock is checked in rounds to keep it out of the measure
+			for !stop.Load() {
+				for end := n + 1024; n < end; n++ {
+					op(n)
+				}
+			}
+			total.Add(int64(n))
+		}()
+	}
+	time.Sleep(d)
+	stop.Store(true)
+	wg.Wait()
+	return float64(total.Load()) / time.Since(start).Seconds()
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// shells are the completion script generators by shell name
+var shells = map[string]func(w io.Writer, specs []commandSpec){
+	"bash": bashCompletion,
+	"zsh":  zshCompletion,
+	"fish": fishCompletion,
+}
+
+// completionFlags defines the usage of completion, it takes no flags
+func completionFlags(fs *flag.FlagSet) {
+	fs.Usage = usageFunc(fs,
+		"usage: rut completion bash|zsh|fish",
+		"\n\tbash: source <(rut completion bash)",
+		"\tzsh:  rut completion zsh > \"${fpath[1]}/_rut\"",
+		"\tfish: rut completion fish > ~/.config/fish/completions/rut.fish")
+}
+
+// completion prints the completion script of a shell
+func completion(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("completion", stderr)
+	completionFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	gen, ok := shells[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "rut completion: unknown shell %q, expected bash, zsh or fish\n", fs.Arg(0))
+		return exitUsage
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+	gen(w, specs())
+	return exitOK
+}
+
+// helpFlags defines the usage of help, it takes no flags
+func helpFlags(fs *flag.FlagSet) {
+	fs.Usage = usageFunc(fs, "usage: rut help [command]")
+}
+
+// help prints the usage of a command and its flags, or the commands
+func help(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("help", stderr)
+	helpFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	switch fs.NArg() {
+	case 0:
+		usage(stdout)
+		return exitOK
+	case 1:
+	default:
+		fs.Usage()
+		return exitUsage
+	}
+
+	name := fs.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "rut help: unknown command %q\n", name)
+		return exitUsage
+	}
+	fmt.Fprintf(stdout, "rut %s: %s\n\n", name, cmd.usage)
+	cfs := newFlagSet(name, stdout)
+	cmd.flags(cfs)
+	cfs.Usage()
+	return exitOK
+}
+
+// commandSpec is a command as offered by the completions
+type commandSpec struct {
+	name  string
+	usage string
+	flags []flagSpec
+
+	// args are the choices of the arguments, files marks them as file names
+	args  []string
+	files bool
+}
+
+// flagSpec is a flag as offered by the completions
+type flagSpec struct {
+	name  string
+	usage string
+
+	// value is set by the flags taking a value, which is one of values
+	// when set or a file name with file
+	value  bool
+	values []string
+	file   bool
+}
+
+// specs describes every command, sorted by name
+func specs() []commandSpec {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	shellnames := make([]string, 0, len(shells))
+	for name := range shells {
+		shellnames = append(shellnames, name)
+	}
+	sort.Strings(shellnames)
+
+	specs := make([]commandSpec, len(names))
+	for i, name := range names {
+		spec := commandSpec{name: name, usage: commands[name].usage, files: commands[name].files}
+		switch name {
+		case "completion":
+			spec.args = shellnames
+		case "help":
+			spec.args = names
+		}
+
+		fs := newFlagSet(name, io.Discard)
+		commands[name].flags(fs)
+		fs.VisitAll(func(f *flag.Flag) {
+			arg, usage := flag.UnquoteUsage(f)
+			fl := flagSpec{name: f.Name, usage: usage, value: true, file: arg == "file"}
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+				fl.value = false
+			}
+			if c, ok := f.Value.(*choiceValue); ok {
+				fl.values = c.values
+			}
+			spec.flags = append(spec.flags, fl)
+		})
+		specs[i] = spec
+	}
+	return specs
+}
+
+func bashCompletion(w io.Writer, specs []commandSpec) {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.name
+	}
+
+	fmt.Fprintln(w, "# bash completion for rut, generated by 'rut completion bash'")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_rut() {")
+	fmt.Fprintln(w, "\tlocal cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}")
+	fmt.Fprintln(w, "\tCOMPREPLY=()")
+	fmt.Fprintln(w, "\tif [[ $COMP_CWORD -eq 1 ]]; then")
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintln(w, "\t\treturn")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "\tlocal flags= args= files=")
+	fmt.Fprintln(w, "\tcase ${COMP_WORDS[1]} in")
+	for _, spec := range specs {
+		fmt.Fprintf(w, "\t%s)\n", spec.name)
+
+		// the values of the flags are completed after them, free form
+		// values aren't completed at all
+		var flags, cases, freeform []string
+		for _, f := range spec.flags {
+			flags = append(flags, "-"+f.name)
+			switch {
+			case f.values != nil:
+				cases = append(cases, fmt.Sprintf("-%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;", f.name, strings.Join(f.values, " ")))
+			case f.file:
+				cases = append(cases, fmt.Sprintf("-%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;", f.name))
+			case f.value:
+				freeform = append(freeform, "-"+f.name)
+			}
+		}
+		if freeform != nil {
+			cases = append(cases, strings.Join(freeform, "|")+") return ;;")
+		}
+		if cases != nil {
+			fmt.Fprintln(w, "\t\tcase $prev in")
+			for _, c := range cases {
+				fmt.Fprintf(w, "\t\t%s\n", c)
+			}
+			fmt.Fprintln(w, "\t\tesac")
+		}
+		fmt.Fprintf(w, "\t\tflags=%q\n", strings.Join(flags, " "))
+		if spec.args != nil {
+			fmt.Fprintf(w, "\t\targs=%q\n", strings.Join(spec.args, " "))
+		}
+		if spec.files {
+			fmt.Fprintln(w, "\t\tfiles=1")
+		}
+		fmt.Fprintln(w, "\t\t;;")
+	}
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "\tif [[ $cur == -* ]]; then")
+	fmt.Fprintln(w, "\t\tCOMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))")
+	fmt.Fprintln(w, "\telif [[ -n $args ]]; then")
+	fmt.Fprintln(w, "\t\tCOMPREPLY=($(compgen -W \"$args\" -- \"$cur\"))")
+	fmt.Fprintln(w, "\telif [[ -n $files ]]; then")
+	fmt.Fprintln(w, "\t\tCOMPREPLY=($(compgen -f -- \"$cur\"))")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "complete -o filenames -F _rut rut")
+}
+
+// zshQuote quotes s for a zsh single quoted _arguments spec
+var zshQuote = strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`)
+
+func zshCompletion(w io.Writer, specs []commandSpec) {
+	fmt.Fprintln(w, "#compdef rut")
+	fmt.Fprintln(w, "# zsh completion for rut, generated by 'rut completion zsh'")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_rut() {")
+	fmt.Fprintln(w, "\tlocal line state")
+	fmt.Fprintln(w, "\tlocal -a commands")
+	fmt.Fprintln(w, "\tcommands=(")
+	for _, spec := range specs {
+		fmt.Fprintf(w, "\t\t'%s:%s'\n", spec.name, zshQuote.Replace(spec.usage))
+	}
+	fmt.Fprintln(w, "\t)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "\t_arguments -C '1: :->command' '*:: :->args'")
+	fmt.Fprintln(w, "\tcase $state in")
+	fmt.Fprintln(w, "\tcommand)")
+	fmt.Fprintln(w, "\t\t_describe -t commands command commands")
+	fmt.Fprintln(w, "\t\t;;")
+	fmt.Fprintln(w, "\targs)")
+	fmt.Fprintln(w, "\t\tcase $line[1] in")
+	for _, spec := range specs {
+		var args []string
+		for _, f := range spec.flags {
+			arg := fmt.Sprintf("'-%s[%s]", f.name, zshQuote.Replace(f.usage))
+			switch {
+			case f.values != nil:
+				arg += fmt.Sprintf(":%s:(%s)", f.name, strings.Join(f.values, " "))
+			case f.file:
+				arg += ":file:_files"
+			case f.value:
+				arg += fmt.Sprintf(":%s: ", f.name)
+			}
+			args = append(args, arg+"'")
+		}
+		switch {
+		case spec.args != nil:
+			args = append(args, fmt.Sprintf("'1:argument:(%s)'", strings.Join(spec.args, " ")))
+		case spec.files:
+			args = append(args, "'*:file:_files'")
+		}
+		if args == nil {
+			continue
+		}
+
+		fmt.Fprintf(w, "\t\t%s)\n", spec.name)
+		fmt.Fprintf(w, "\t\t\t_arguments \\\n\t\t\t\t%s\n", strings.Join(args, " \\\n\t\t\t\t"))
+		fmt.Fprintln(w, "\t\t\t;;")
+	}
+	fmt.Fprintln(w, "\t\tesac")
+	fmt.Fprintln(w, "\t\t;;")
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `if [ "$funcstack[1]" = "_rut" ]; then`)
+	fmt.Fprintln(w, `	_rut "$@"`)
+	fmt.Fprintln(w, "else")
+	fmt.Fprintln(w, "\tcompdef _rut rut")
+	fmt.Fprintln(w, "fi")
+}
+
+// fishQuote quotes s for a fish single quoted string
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func fishCompletion(w io.Writer, specs []commandSpec) {
+	fmt.Fprintln(w, "# fish completion for rut, generated by 'rut completion fish'")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "complete -c rut -f")
+	for _, spec := range specs {
+		fmt.Fprintf(w, "complete -c rut -n __fish_use_subcommand -a %s -d %s\n", spec.name, fishQuote(spec.usage))
+	}
+	for _, spec := range specs {
+		cond := fishQuote("__fish_seen_subcommand_from " + spec.name)
+		for _, f := range spec.flags {
+			opt := "-o"
+			if len(f.name) == 1 {
+				opt = "-s"
+			}
+			line := fmt.Sprintf("complete -c rut -n %s %s %s", cond, opt, f.name)
+			switch {
+			case f.values != nil:
+				line += " -x -a " + fishQuote(strings.Join(f.values, " "))
+			case f.file:
+				line += " -r -F"
+			case f.value:
+				line += " -x"
+			}
+			fmt.Fprintln(w, line, "-d", fishQuote(f.usage))
+		}
+		switch {
+		case spec.args != nil:
+			fmt.Fprintf(w, "complete -c rut -n %s -a %s\n", cond, fishQuote(strings.Join(spec.args, " ")))
+		case spec.files:
+			fmt.Fprintf(w, "complete -c rut -n %s -F\n", cond)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"unicode/utf8"
+)
+
+// delimiter splits the stdin and file inputs into records instead of
+// lines, set by the -0 and -delimiter flags
+type delimiter struct {
+	nul bool
+	sep string
+}
+
+// delimiterFlags registers -0 and -delimiter on fs, prefix is prepended
+// to their usage, eg. "lines: "
+func delimiterFlags(fs *flag.FlagSet, prefix string) *delimiter {
+	d := &delimiter{}
+	fs.BoolVar(&d.nul, "0", false, prefix+"records are NUL terminated, as written by find -print0")
+	fs.StringVar(&d.sep, "delimiter", "", prefix+"also split the records on `char`, eg. ';'")
+	return d
+}
+
+// split returns the bufio.SplitFunc of the records, lines by default.
+// with -0 or -delimiter the empty records are skipped
+func (d *delimiter) split() (bufio.SplitFunc, error) {
+	switch {
+	case d.nul && d.sep != "":
+		return nil, errors.New("-0 and -delimiter are exclusive")
+	case d.nul:
+		return splitOn("\x00", false), nil
+	case d.sep == "":
+		return bufio.ScanLines, nil
+	case len(d.sep) != 1 || d.sep[0] >= utf8.RuneSelf || d.sep == "\n":
+		return nil, errors.New("-delimiter must be a single ASCII character other than newline")
+	}
+	return splitOn(d.sep+"\n", true), nil
+}
+
+// set reports whether -0 or -delimiter was given
+func (d *delimiter) set() bool {
+	return d.nul || d.sep != ""
+}
+
+// terminator is written after every output record, NUL with -0 so the
+// output composes with xargs -0
+func (d *delimiter) terminator() string {
+	if d.nul {
+		return "\x00"
+	}
+	return "\n"
+}
+
+// splitOn splits on any of the bytes of seps, dropping a '\r' ending the
+// records when dropCR is set
+func splitOn(seps string, dropCR bool) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		i := bytes.IndexAny(data, seps)
+		switch {
+		case i >= 0:
+			advance, token = i+1, data[:i]
+		case atEOF:
+			advance, token = len(data), data
+		default:
+			return 0, nil, nil
+		}
+		if dropCR {
+			token = bytes.TrimSuffix(token, []byte{'\r'})
+		}
+		if len(token) == 0 {
+			token = nil
+		}
+		return
+	}
+}
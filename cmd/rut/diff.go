@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alvarolm/rut"
+)
+
+// diffFlags are the flags of diff
+type diffFlags struct {
+	both  *bool
+	delim *delimiter
+}
+
+func newDiffFlags(fs *flag.FlagSet) *diffFlags {
+	fs.Usage = usageFunc(fs, "usage: rut diff [-both] [-0 | -delimiter char] a.txt b.txt")
+	return &diffFlags{
+		both:  fs.Bool("both", false, "also print the ruts present in both files"),
+		delim: delimiterFlags(fs, ""),
+	}
+}
+
+// diff compares two files of ruts, one per line, printing the ones only
+// in the first prefixed by '<', the ones only in the second prefixed by
+// '>' and, with -both, the common ones prefixed by '='
+func diff(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("diff", stderr)
+	f := newDiffFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	split, err := f.delim.split()
+	if err != nil {
+		fmt.Fprintln(stderr, "rut diff:", err)
+		return exitUsage
+	}
+
+	var files [2]io.Reader
+	for i, name := range fs.Args() {
+		if name == "-" {
+			files[i] = stdin
+			continue
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(stderr, "rut diff:", err)
+			return exitUsage
+		}
+		defer f.Close()
+		files[i] = f
+	}
+
+	var errA, errB error
+	onlyA, onlyB, common := rut.Diff(lines(files[0], split, &errA), lines(files[1], split, &errB))
+	for _, err := range []error{errA, errB} {
+		if err != nil {
+			fmt.Fprintln(stderr, "rut diff:", err)
+			return exitUsage
+		}
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+	for _, r := range onlyA {
+		fmt.Fprintln(w, "<", r)
+	}
+	for _, r := range onlyB {
+		fmt.Fprintln(w, ">", r)
+	}
+	if *f.both {
+		for _, r := range common {
+			fmt.Fprintln(w, "=", r)
+		}
+	}
+
+	if len(onlyA) > 0 || len(onlyB) > 0 {
+		return exitFail
+	}
+	return exitOK
+}
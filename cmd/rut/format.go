@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alvarolm/rut"
+)
+
+// formatFlags are the flags of format
+type formatFlags struct {
+	style *string
+	delim *delimiter
+}
+
+func newFormatFlags(fs *flag.FlagSet) *formatFlags {
+	styles := styleNames()
+	fs.Usage = usageFunc(fs, "usage: rut format [-style style] [-0 | -delimiter char] [file ...]")
+	return &formatFlags{
+		style: choice(fs, "style", "canonical", strings.Join(styles, ", "), styles...),
+		delim: delimiterFlags(fs, ""),
+	}
+}
+
+// format rewrites the ruts of the files, or stdin, in the chosen style.
+// invalid lines are written unchanged and reported on stderr, with -0 the
+// output records are NUL terminated too
+func format(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("format", stderr)
+	f := newFormatFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	style, err := rut.ParseStyle(*f.style)
+	if err != nil {
+		fmt.Fprintln(stderr, "rut format:", err)
+		return exitUsage
+	}
+	split, err := f.delim.split()
+	if err != nil {
+		fmt.Fprintln(stderr, "rut format:", err)
+		return exitUsage
+	}
+
+	inputs := []io.Reader{stdin}
+	names := []string{"stdin"}
+	if fs.NArg() > 0 {
+		inputs, names = nil, fs.Args()
+		for _, name := range names {
+			f, err := os.Open(name)
+			if err != nil {
+				fmt.Fprintln(stderr, "rut format:", err)
+				return exitUsage
+			}
+			defer f.Close()
+			inputs = append(inputs, f)
+		}
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+
+	code := exitOK
+	for i, in := range inputs {
+		sc := bufio.NewScanner(in)
+		sc.Split(split)
+		for line := 1; sc.Scan(); line++ {
+			r := rut.Rut(sc.Text())
+			s, err := r.Format(rut.FormatOptions{Style: style})
+			if err != nil {
+				code = exitFail
+				fmt.Fprintf(stderr, "%s:%d: %q: %s\n", names[i], line, sc.Text(), err)
+				s = sc.Text()
+			}
+			fmt.Fprint(w, s, f.delim.terminator())
+		}
+		if err := sc.Err(); err != nil {
+			fmt.Fprintln(stderr, "rut format:", err)
+			return exitUsage
+		}
+	}
+	return code
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/alvarolm/rut"
+)
+
+// generateFlags are the flags of generate
+type generateFlags struct {
+	n, min, max  *int
+	kind, format *string
+	unique       *bool
+	seed         *int64
+}
+
+func newGenerateFlags(fs *flag.FlagSet) *generateFlags {
+	styles := styleNames()
+	fs.Usage = usageFunc(fs, "usage: rut generate [flags]")
+	return &generateFlags{
+		n:      fs.Int("n", 10, "number of ruts"),
+		min:    fs.Int("min", 0, "minimum 'cuerpo' (inclusive), defaults to the kind range"),
+		max:    fs.Int("max", 0, "maximum 'cuerpo' (exclusive), defaults to the kind range"),
+		kind:   choice(fs, "kind", "any", "any, person or company", "any", "person", "company"),
+		format: choice(fs, "format", "plain", "output `style`: "+strings.Join(styles, ", "), styles...),
+		unique: fs.Bool("unique", false, "never print the same rut twice"),
+		seed:   fs.Int64("seed", 0, "random seed for reproducible output, 0 picks a random one"),
+	}
+}
+
+// generate prints random valid ruts, one per line
+func generate(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("generate", stderr)
+	f := newGenerateFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 || *f.n < 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	opts := rut.GenerateOptions{Min: *f.min, Max: *f.max, Unique: *f.unique}
+	var err error
+	if opts.Kind, err = rut.ParseKind(*f.kind); err != nil {
+		fmt.Fprintln(stderr, "rut generate:", err)
+		return exitUsage
+	}
+	style, err := rut.ParseStyle(*f.format)
+	if err != nil {
+		fmt.Fprintln(stderr, "rut generate:", err)
+		return exitUsage
+	}
+	if *f.seed != 0 {
+		opts.Rand = rand.New(rand.NewSource(*f.seed))
+	}
+
+	g, err := rut.NewGenerator(opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "rut generate:", err)
+		return exitUsage
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+	for i := 0; i < *f.n; i++ {
+		r, err := g.Next()
+		if err != nil {
+			fmt.Fprintln(stderr, "rut generate:", err)
+			return exitFail
+		}
+		s, err := r.Format(rut.FormatOptions{Style: style})
+		if err != nil {
+			// the range holds 'cuerpos' out of MinRutlength and MaxRutlength
+			fmt.Fprintln(stderr, "rut generate:", err)
+			return exitUsage
+		}
+		fmt.Fprintln(w, s)
+	}
+	return exitOK
+}
@@ -0,0 +1,163 @@
+/*
+Command rut validates and generates 'Rol Único Tributario'
+
+	rut <command> [flags] [arguments]
+
+exit codes are 0 on success, 1 when the command found invalid or
+differing ruts and 2 on usage or input errors. 'rut help <command>'
+describes the flags of a command and 'rut completion bash|zsh|fish'
+prints a shell completion script
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sort"
+
+	"github.com/alvarolm/rut"
+)
+
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+type command struct {
+	usage string
+	run   func(stdin io.Reader, stdout, stderr io.Writer, args []string) int
+
+	// flags defines the flags and the usage of the command on a flag set,
+	// run parses them and help and the completions describe them
+	flags func(fs *flag.FlagSet)
+
+	// files marks the arguments as file names for the completions
+	files bool
+}
+
+var commands = map[string]command{
+	"bench":    {"measure the validations and generations per second", bench, flagsOf(newBenchFlags), false},
+	"diff":     {"compare two lists of ruts", diff, flagsOf(newDiffFlags), true},
+	"format":   {"rewrite ruts in a chosen style", format, flagsOf(newFormatFlags), true},
+	"generate": {"generate random valid ruts", generate, flagsOf(newGenerateFlags), false},
+	"repair":   {"recompute the 'digito verificador' of ruts and 'cuerpos'", repair, flagsOf(newRepairFlags), true},
+	"synth":    {"generate a synthetic dataset shaped as real ruts", synth, flagsOf(newSynthFlags), true},
+	"validate": {"validate ruts from the arguments or stdin", validate, flagsOf(newValidateFlags), true},
+}
+
+// the commands listing the others are added at init to break the
+// initialization cycle
+func init() {
+	commands["completion"] = command{"print the bash, zsh or fish completion script", completion, completionFlags, false}
+	commands["help"] = command{"describe a command and its flags", help, helpFlags, false}
+}
+
+// newFlagSet returns the flag set of a command writing its usage and
+// errors to stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// flagsOf adapts the flags constructor of a command to command.flags
+func flagsOf[T any](newFlags func(fs *flag.FlagSet) T) func(fs *flag.FlagSet) {
+	return func(fs *flag.FlagSet) { newFlags(fs) }
+}
+
+// usageFunc returns the fs.Usage printing the synopsis lines and the flags
+func usageFunc(fs *flag.FlagSet, synopsis ...string) func() {
+	return func() {
+		for _, line := range synopsis {
+			fmt.Fprintln(fs.Output(), line)
+		}
+		fs.PrintDefaults()
+	}
+}
+
+// styleNames are the names rut.ParseStyle accepts, "plain" first
+func styleNames() []string {
+	names := []string{"plain"}
+	for s := rut.StyleCanonical; ; s++ {
+		if _, err := rut.ParseStyle(s.String()); err != nil {
+			return names
+		}
+		names = append(names, s.String())
+	}
+}
+
+// choiceValue is a string flag value taking one of values, which are
+// offered by the completions. the commands validate it themselves
+type choiceValue struct {
+	value  string
+	values []string
+}
+
+func (c *choiceValue) String() string     { return c.value }
+func (c *choiceValue) Set(s string) error { c.value = s; return nil }
+
+// choice defines a string flag taking one of values
+func choice(fs *flag.FlagSet, name, value, usage string, values ...string) *string {
+	c := &choiceValue{value, values}
+	fs.Var(c, name, usage)
+	return &c.value
+}
+
+func main() {
+	os.Exit(run(os.Stdin, os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "rut: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+	return cmd.run(stdin, stdout, stderr, args[1:])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: rut <command> [flags] [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w, "\nrun 'rut help <command>' for the flags of a command")
+}
+
+// lines yields one rut per record of r split by split, scanning errors
+// are stored in err
+func lines(r io.Reader, split bufio.SplitFunc, err *error) iter.Seq[rut.Rut] {
+	return func(yield func(rut.Rut) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Split(split)
+		for sc.Scan() {
+			if !yield(rut.Rut(sc.Text())) {
+				return
+			}
+		}
+		*err = sc.Err()
+	}
+}
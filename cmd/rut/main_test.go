@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestUsage(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run(nil, &bytes.Buffer{}, &stderr, nil); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+	if code := run(nil, &bytes.Buffer{}, &stderr, []string{"nope"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"-h"}); code != exitOK || !strings.Contains(stdout.String(), "rut help <command>") {
+		t.Error("unexpected usage", code, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"help", "format"}); code != exitOK {
+		t.Error("expected", exitOK, "got", code)
+	}
+	if out := stdout.String(); !strings.HasPrefix(out, "rut format: ") || !strings.Contains(out, "-style") {
+		t.Error("unexpected help", out)
+	}
+
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"help", "nope"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for shell, expected := range map[string][]string{
+		"bash": {"complete -o filenames -F _rut rut", `-style) COMPREPLY=($(compgen -W "plain canonical dotted fixed-width numeric masked" -- "$cur"))`, `flags="-0 -delimiter -style"`},
+		"zsh":  {"#compdef rut", "'-output[output format: text, json, csv or tsv]:output:(text json csv tsv)'", "'-log[write the changes as csv to file, - for stderr]:file:_files'"},
+		"fish": {"complete -c rut -n __fish_use_subcommand -a validate", "-o kind -x -a 'any person company'", "-o format -x -a 'plain canonical dotted fixed-width numeric masked'", "-s q -d 'print nothing, only set the exit code'"},
+	} {
+		var stdout bytes.Buffer
+		if code := run(nil, &stdout, &bytes.Buffer{}, []string{"completion", shell}); code != exitOK {
+			t.Fatal(shell, "expected", exitOK, "got", code)
+		}
+		for _, s := range expected {
+			if !strings.Contains(stdout.String(), s) {
+				t.Errorf("%s: expected %q in\n%s", shell, s, stdout.String())
+			}
+		}
+	}
+
+	if code := run(nil, &bytes.Buffer{}, &bytes.Buffer{}, []string{"completion", "tcsh"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(a, []byte("12.345.678-5\n11111111-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	code := run(strings.NewReader("12345678-5\n13117182-k\n"), &stdout, &bytes.Buffer{}, []string{"diff", "-both", a, "-"})
+	if code != exitFail {
+		t.Error("expected", exitFail, "got", code)
+	}
+	if expected := "< 11111111-1\n> 13117182-K\n= 12345678-5\n"; stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var stdout bytes.Buffer
+	code := run(nil, &stdout, &bytes.Buffer{}, []string{"validate", "11.111.111-1", "12345678-0", "1-9"})
+	if code != exitFail {
+		t.Error("expected", exitFail, "got", code)
+	}
+
+	expected := "11.111.111-1\tvalid\t11111111-1\n" +
+		"12345678-0\tinvalid\tinvalid 'digito verificador', expected 5\n" +
+		"1-9\tinvalid\tlength less than expected\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+
+	stdout.Reset()
+	code = run(strings.NewReader("11111111-1\n\n13117182-k\n"), &stdout, &bytes.Buffer{}, []string{"validate", "-q"})
+	if code != exitOK || stdout.Len() != 0 {
+		t.Error("expected silent success, got", code, stdout.String())
+	}
+
+	stdout.Reset()
+	run(nil, &stdout, &bytes.Buffer{}, []string{"validate", "-sort", "bytes", "12345678-0", "9.999.999-3", "10000000-8"})
+	expected = "10000000-8\tvalid\t10000000-8\n" +
+		"9.999.999-3\tvalid\t9999999-3\n" +
+		"12345678-0\tinvalid\tinvalid 'digito verificador', expected 5\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"validate", "-sort", "random"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"validate", "-nope"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var a, b bytes.Buffer
+	args := []string{"generate", "-n", "5", "-seed", "7", "-kind", "company", "-format", "dotted", "-unique"}
+	if code := run(nil, &a, &bytes.Buffer{}, args); code != exitOK {
+		t.Fatal("expected", exitOK, "got", code)
+	}
+	run(nil, &b, &bytes.Buffer{}, args)
+	if a.String() != b.String() {
+		t.Error("expected the same output for the same seed")
+	}
+
+	lines := strings.Split(strings.TrimSpace(a.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatal("expected 5 ruts, got", lines)
+	}
+	for _, line := range lines {
+		r := rut.Rut(line)
+		if k, err := r.Kind(); err != nil || k != rut.KindCompany || !strings.Contains(line, ".") {
+			t.Error("unexpected", line, k, err)
+		}
+	}
+
+	if code := run(nil, &a, &bytes.Buffer{}, []string{"generate", "-n", "3", "-min", "10000000", "-max", "10000002", "-unique"}); code != exitFail {
+		t.Error("expected", exitFail, "once exhausted, got", code)
+	}
+	if code := run(nil, &a, &bytes.Buffer{}, []string{"generate", "-format", "nope"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+
+	// 'cuerpos' longer than MaxRutlength can't be formatted
+	var stderr bytes.Buffer
+	if code := run(nil, &a, &stderr, []string{"generate", "-min", "1000000000", "-max", "1000000010"}); code != exitUsage || stderr.Len() == 0 {
+		t.Error("expected", exitUsage, "got", code, stderr.String())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(strings.NewReader("12345678-5\n13.117.182-k\nnope\n"), &stdout, &stderr, []string{"format", "-style", "dotted"})
+	if code != exitFail {
+		t.Error("expected", exitFail, "got", code)
+	}
+	if expected := "12.345.678-5\n13.117.182-K\nnope\n"; stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+	if !strings.HasPrefix(stderr.String(), "stdin:3:") {
+		t.Error("expected stdin:3 error, got", stderr.String())
+	}
+}
+
+func TestDelimiter(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(strings.NewReader("12345678-5\x0013.117.182-k\x00"), &stdout, &stderr, []string{"format", "-0", "-style", "dotted"})
+	if code != exitOK {
+		t.Error("expected", exitOK, "got", code, stderr.String())
+	}
+	if expected := "12.345.678-5\x0013.117.182-K\x00"; stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+
+	stdout.Reset()
+	code = run(strings.NewReader("11111111-1;12345678-5;\r\n13117182-k\n"), &stdout, &stderr, []string{"validate", "-delimiter", ";", "-output", "csv"})
+	if code != exitOK {
+		t.Error("expected", exitOK, "got", code, stderr.String())
+	}
+	if n := strings.Count(stdout.String(), ",true,"); n != 3 {
+		t.Error("expected 3 valid ruts, got", n, stdout.String())
+	}
+
+	for _, args := range [][]string{
+		{"validate", "-0", "-delimiter", ";"},
+		{"validate", "-delimiter", ";;"},
+		{"format", "-delimiter", "ñ"},
+		{"validate", "-in", "csv", "-0"},
+		{"validate", "-in", "jsonl", "-field", "rut", "-delimiter", ";"},
+	} {
+		if code := run(strings.NewReader(""), &stdout, &stderr, args); code != exitUsage {
+			t.Error(args, "expected", exitUsage, "got", code)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "changes.csv")
+
+	var stdout, stderr bytes.Buffer
+	code := run(strings.NewReader("12.345.678\n11111111-1\n13117182-0\nnope\n"), &stdout, &stderr, []string{"repair", "-log", log})
+	if code != exitFail {
+		t.Error("expected", exitFail, "got", code)
+	}
+	if expected := "12345678-5\n11111111-1\n13117182-K\nnope\n"; stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+	if !strings.HasPrefix(stderr.String(), "stdin:4:") {
+		t.Error("expected stdin:4 error, got", stderr.String())
+	}
+
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "file,line,input,output,repair\n" +
+		"stdin,1,12.345.678,12345678-5,dv_computed\n" +
+		"stdin,3,13117182-0,13117182-K,dv_corrected\n"
+	if string(b) != expected {
+		t.Errorf("expected %q, got %q", expected, b)
+	}
+}
+
+func TestSynth(t *testing.T) {
+	in := "12.345.678-5\n76086428-5\n12345678-5\nnope\n"
+
+	var stdout bytes.Buffer
+	if code := run(strings.NewReader(in), &stdout, &bytes.Buffer{}, []string{"synth", "-seed", "1"}); code != exitOK {
+		t.Fatal("expected", exitOK, "got", code)
+	}
+	out := strings.Fields(stdout.String())
+	if len(out) != 2 {
+		t.Fatal("unexpected output", out)
+	}
+	kinds := map[rut.Kind]int{}
+	for _, s := range out {
+		r := rut.Rut(s)
+		k, err := r.Kind()
+		if err != nil || r == "12345678-5" || r == "76086428-5" {
+			t.Error("unexpected rut", r, err)
+		}
+		kinds[k]++
+	}
+	if kinds[rut.KindPerson] != 1 || kinds[rut.KindCompany] != 1 {
+		t.Error("unexpected kinds", kinds)
+	}
+}
+
+func TestValidateDetect(t *testing.T) {
+	in := "uno,11.111.111-1\ndos,13117182-k\n"
+
+	var stdout, stderr bytes.Buffer
+	if code := run(strings.NewReader(in), &stdout, &stderr, []string{"validate", "-in", "csv", "-detect", "10", "-output", "csv"}); code != exitOK {
+		t.Fatal("expected", exitOK, "got", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "detected column 1 with confidence 1.00") {
+		t.Error("unexpected stderr", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "11111111-1") || !strings.Contains(stdout.String(), "13117182-K") {
+		t.Error("unexpected output", stdout.String())
+	}
+
+	if code := run(strings.NewReader("a,b\n"), &stdout, &stderr, []string{"validate", "-in", "csv", "-detect", "10"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
+
+func TestValidateHasHeader(t *testing.T) {
+	in := "nombre,rut\nuno,11.111.111-1\ndos,13117182-k\n"
+
+	var stdout, stderr bytes.Buffer
+	if code := run(strings.NewReader(in), &stdout, &stderr, []string{"validate", "-in", "csv", "-column", "1", "-q"}); code != exitFail {
+		t.Error("expected the header to fail, got", code)
+	}
+	for _, args := range [][]string{{"-column", "1"}, {"-detect", "10"}} {
+		args = append([]string{"validate", "-in", "csv", "-has-header", "-report", "-"}, args...)
+		stderr.Reset()
+		if code := run(strings.NewReader(in), &stdout, &stderr, args); code != exitOK {
+			t.Error(args, "expected", exitOK, "got", code, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), `"total": 2,`) {
+			t.Error(args, "unexpected report", stderr.String())
+		}
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	csvfile := filepath.Join(dir, "in.csv")
+	if err := os.WriteFile(csvfile, []byte("nombre;rut\nuno;11.111.111-1\ndos;12345678-0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reportfile := filepath.Join(dir, "report.json")
+
+	var stdout bytes.Buffer
+	code := run(nil, &stdout, &bytes.Buffer{}, []string{"validate", "-in", "csv", "-header", "rut", "-comma", ";", "-output", "tsv", "-report", reportfile, csvfile})
+	if code != exitFail {
+		t.Error("expected", exitFail, "got", code)
+	}
+
+	expected := "record\tinput\tvalid\tnormalized\texpected_dv\tcode\terror\n" +
+		"1\t11.111.111-1\ttrue\t11111111-1\t1\t\t\n" +
+		"2\t12345678-0\tfalse\t\t5\tinvalid_dv\tinvalid 'digito verificador'\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+
+	report, err := os.ReadFile(reportfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(report), `"total": 2`) {
+		t.Error("unexpected report", string(report))
+	}
+
+	stdout.Reset()
+	code = run(strings.NewReader(`{"customer":{"rut":"13117182-k"}}`), &stdout, &bytes.Buffer{}, []string{"validate", "-in", "jsonl", "-field", "customer.rut", "-output", "json"})
+	if code != exitOK {
+		t.Error("expected", exitOK, "got", code)
+	}
+	if expected := `{"record":1,"input":"13117182-k","valid":true,"normalized":"13117182-K","expected_dv":"K"}` + "\n"; stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestBench(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"bench", "-cpu", "2", "-duration", "20ms"}); code != exitOK {
+		t.Fatal("expected", exitOK, "got", code)
+	}
+	out := stdout.String()
+	for _, prefix := range []string{"cpu       2\n", "validate  ", "generate  "} {
+		if !strings.Contains(out, prefix) {
+			t.Errorf("expected %q in %q", prefix, out)
+		}
+	}
+	if strings.Contains(out, " 0/s") {
+		t.Error("expected some throughput, got", out)
+	}
+
+	if code := run(nil, &stdout, &bytes.Buffer{}, []string{"bench", "-cpu", "0"}); code != exitUsage {
+		t.Error("expected", exitUsage, "got", code)
+	}
+}
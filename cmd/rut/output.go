@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/alvarolm/rut"
+)
+
+// resultWriter renders validation results in one of the -output formats
+type resultWriter interface {
+	write(n int, res rut.Result) error
+	flush() error
+}
+
+func newResultWriter(w io.Writer, output string) (resultWriter, error) {
+	switch output {
+	case "text":
+		return &textWriter{bufio.NewWriter(w)}, nil
+	case "json":
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		enc.SetEscapeHTML(false)
+		return &jsonWriter{bw, enc}, nil
+	case "csv", "tsv":
+		cw := csv.NewWriter(w)
+		if output == "tsv" {
+			cw.Comma = '\t'
+		}
+		return &csvWriter{w: cw}, nil
+	default:
+		return nil, fmt.Errorf("unknown output %q, expected text, json, csv or tsv", output)
+	}
+}
+
+type textWriter struct {
+	w *bufio.Writer
+}
+
+func (t *textWriter) write(_ int, res rut.Result) error {
+	printResult(t.w, res)
+	return nil
+}
+
+func (t *textWriter) flush() error {
+	return t.w.Flush()
+}
+
+// printResult writes 'input	valid	normalized' or 'input	invalid	reason'
+func printResult(w io.Writer, res rut.Result) {
+	if res.Valid() {
+		fmt.Fprintf(w, "%s\tvalid\t%s\n", res.Input, res.Rut)
+		return
+	}
+	if res.Err == rut.ErrinvalidDV {
+		fmt.Fprintf(w, "%s\tinvalid\t%s, expected %c\n", res.Input, res.Err, res.ExpectedDV)
+		return
+	}
+	fmt.Fprintf(w, "%s\tinvalid\t%s\n", res.Input, res.Err)
+}
+
+// record is the json, csv and tsv form of a result
+type record struct {
+	Record     int    `json:"record"`
+	Input      string `json:"input"`
+	Valid      bool   `json:"valid"`
+	Normalized string `json:"normalized,omitempty"`
+	ExpectedDV string `json:"expected_dv,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newRecord(n int, res rut.Result) (rec record) {
+	rec = record{
+		Record:     n,
+		Input:      res.Input,
+		Valid:      res.Valid(),
+		Normalized: string(res.Rut),
+		Code:       rut.Code(res.Err),
+	}
+	if res.ExpectedDV != 0 {
+		rec.ExpectedDV = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		rec.Error = res.Err.Error()
+	}
+	return
+}
+
+type jsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonWriter) write(n int, res rut.Result) error {
+	return j.enc.Encode(newRecord(n, res))
+}
+
+func (j *jsonWriter) flush() error {
+	return j.w.Flush()
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvWriter) write(n int, res rut.Result) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write([]string{"record", "input", "valid", "normalized", "expected_dv", "code", "error"}); err != nil {
+			return err
+		}
+	}
+	rec := newRecord(n, res)
+	return c.w.Write([]string{
+		fmt.Sprint(rec.Record), rec.Input, fmt.Sprint(rec.Valid),
+		rec.Normalized, rec.ExpectedDV, rec.Code, rec.Error,
+	})
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// sortedWriter holds the results until flush to write them in order
+type sortedWriter struct {
+	w       resultWriter
+	order   rut.Order
+	results []numbered
+}
+
+type numbered struct {
+	n   int
+	res rut.Result
+}
+
+func (s *sortedWriter) write(n int, res rut.Result) error {
+	s.results = append(s.results, numbered{n, res})
+	return nil
+}
+
+func (s *sortedWriter) flush() error {
+	slices.SortStableFunc(s.results, func(a, b numbered) int {
+		return s.order.Compare(a.res, b.res)
+	})
+	for _, r := range s.results {
+		if err := s.w.write(r.n, r.res); err != nil {
+			return err
+		}
+	}
+	return s.w.flush()
+}
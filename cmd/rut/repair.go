@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/alvarolm/rut"
+)
+
+// newRepairFlags defines the flags of repair, it returns -log
+func newRepairFlags(fs *flag.FlagSet) *string {
+	fs.Usage = usageFunc(fs, "usage: rut repair [-log file] [file ...]")
+	return fs.String("log", "", "write the changes as csv to `file`, - for stderr")
+}
+
+// repair rewrites the 'cuerpos' and ruts of the files, or stdin, with their
+// right 'digito verificador'. the changes are logged as csv to -log and the
+// lines that can't be repaired are written unchanged and reported on stderr
+func repair(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("repair", stderr)
+	logpath := newRepairFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var changes *csv.Writer
+	if *logpath != "" {
+		w := stderr
+		if *logpath != "-" {
+			f, err := os.Create(*logpath)
+			if err != nil {
+				fmt.Fprintln(stderr, "rut repair:", err)
+				return exitUsage
+			}
+			defer f.Close()
+			w = f
+		}
+		changes = csv.NewWriter(w)
+		changes.Write([]string{"file", "line", "input", "output", "repair"})
+		defer changes.Flush()
+	}
+
+	inputs := []io.Reader{stdin}
+	names := []string{"stdin"}
+	if fs.NArg() > 0 {
+		inputs, names = nil, fs.Args()
+		for _, name := range names {
+			f, err := os.Open(name)
+			if err != nil {
+				fmt.Fprintln(stderr, "rut repair:", err)
+				return exitUsage
+			}
+			defer f.Close()
+			inputs = append(inputs, f)
+		}
+	}
+
+	code := exitOK
+	for i, in := range inputs {
+		_, err := rut.RepairLines(stdout, in, func(c rut.Change) {
+			if c.Err != nil {
+				code = exitFail
+				fmt.Fprintf(stderr, "%s:%d: %q: %s\n", names[i], c.Line, c.Input, c.Err)
+				return
+			}
+			if changes != nil {
+				changes.Write([]string{names[i], strconv.Itoa(c.Line), c.Input, string(c.Output), string(c.Repair)})
+			}
+		})
+		if err != nil {
+			fmt.Fprintln(stderr, "rut repair:", err)
+			return exitUsage
+		}
+	}
+	return code
+}
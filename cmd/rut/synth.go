@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/alvarolm/rut"
+)
+
+// synthFlags are the flags of synth
+type synthFlags struct {
+	seed  *int64
+	delim *delimiter
+}
+
+func newSynthFlags(fs *flag.FlagSet) *synthFlags {
+	fs.Usage = usageFunc(fs, "usage: rut synth [-seed n] [-0 | -delimiter char] [file ...]")
+	return &synthFlags{
+		seed:  fs.Int64("seed", 0, "random seed for reproducible output, 0 picks a random one"),
+		delim: delimiterFlags(fs, ""),
+	}
+}
+
+// synth prints a synthetic dataset shaped as the ruts of the files, or
+// stdin, see rut.Synthesize
+func synth(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("synth", stderr)
+	f := newSynthFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	split, err := f.delim.split()
+	if err != nil {
+		fmt.Fprintln(stderr, "rut synth:", err)
+		return exitUsage
+	}
+
+	var rnd *rand.Rand
+	if *f.seed != 0 {
+		rnd = rand.New(rand.NewSource(*f.seed))
+	}
+
+	all := func(yield func(rut.Rut) bool) {
+		err = eachFile(stdin, fs.Args(), func(r io.Reader) (lerr error) {
+			for x := range lines(r, split, &lerr) {
+				if !yield(x) {
+					break
+				}
+			}
+			return
+		})
+	}
+	out, serr := rut.Synthesize(all, rnd)
+	if err == nil {
+		err = serr
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "rut synth:", err)
+		return exitUsage
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+	for _, r := range out {
+		fmt.Fprintln(w, r)
+	}
+	return exitOK
+}
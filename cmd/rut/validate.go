@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutcsv"
+	"github.com/alvarolm/rut/rutjsonl"
+)
+
+// validateFlags are the flags of validate
+type validateFlags struct {
+	quiet, hasHeader     *bool
+	in, output, sortflag *string
+	delim                *delimiter
+	column, detect       *int
+	header, comma, field *string
+	reportpath           *string
+}
+
+func newValidateFlags(fs *flag.FlagSet) *validateFlags {
+	fs.Usage = usageFunc(fs,
+		"usage: rut validate [flags] [rut ...]",
+		"       rut validate -in csv|jsonl [flags] [file ...]")
+	return &validateFlags{
+		quiet:      fs.Bool("q", false, "print nothing, only set the exit code"),
+		in:         choice(fs, "in", "lines", "input `format`: lines, csv or jsonl", "lines", "csv", "jsonl"),
+		delim:      delimiterFlags(fs, "lines: "),
+		column:     fs.Int("column", 0, "csv: zero based `index` of the rut column"),
+		header:     fs.String("header", "", "csv: `name` of the rut column, the first record is the header"),
+		hasHeader:  fs.Bool("has-header", false, "csv: the first record is a header, with -column or -detect"),
+		comma:      fs.String("comma", ",", "csv: field delimiter"),
+		detect:     fs.Int("detect", 0, "csv: select the rut column scoring best over the first `n` records"),
+		field:      fs.String("field", "", "jsonl: dot separated `path` of the rut field"),
+		output:     choice(fs, "output", "text", "output `format`: text, json, csv or tsv", "text", "json", "csv", "tsv"),
+		sortflag:   choice(fs, "sort", "input", "output `order`: input, numeric or bytes, the invalid ruts last", "input", "numeric", "bytes"),
+		reportpath: fs.String("report", "", "write the summary report as JSON to `file`, - for stderr"),
+	}
+}
+
+// validate prints the status of every rut given as argument, read from
+// stdin one per line when there are none, or found in csv or jsonl files
+func validate(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	fs := newFlagSet("validate", stderr)
+	f := newValidateFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var out resultWriter = discard{}
+	if !*f.quiet {
+		var err error
+		if out, err = newResultWriter(stdout, *f.output); err != nil {
+			fmt.Fprintln(stderr, "rut validate:", err)
+			return exitUsage
+		}
+		order, err := rut.ParseOrder(*f.sortflag)
+		if err != nil {
+			fmt.Fprintln(stderr, "rut validate:", err)
+			return exitUsage
+		}
+		if order != rut.OrderInput {
+			out = &sortedWriter{w: out, order: order}
+		}
+	}
+
+	var report rut.Report
+	emit := func(n int, res rut.Result) error {
+		report.Add(res)
+		return out.write(n, res)
+	}
+
+	if *f.in != "lines" && f.delim.set() {
+		fmt.Fprintln(stderr, "rut validate: -0 and -delimiter require -in lines")
+		return exitUsage
+	}
+
+	var err error
+	switch *f.in {
+	case "lines":
+		var split bufio.SplitFunc
+		if split, err = f.delim.split(); err != nil {
+			fmt.Fprintln(stderr, "rut validate:", err)
+			return exitUsage
+		}
+		err = validateLines(stdin, fs.Args(), split, emit)
+	case "csv":
+		comma, size := utf8.DecodeRuneInString(*f.comma)
+		if size == 0 || size != len(*f.comma) {
+			fmt.Fprintln(stderr, "rut validate: -comma must be a single character")
+			return exitUsage
+		}
+		p := &rutcsv.Processor{Column: *f.column, Header: *f.header, HasHeader: *f.hasHeader, Comma: comma, DetectRows: *f.detect}
+		if !*f.quiet {
+			p.OnDetect = func(d rutcsv.Detection) {
+				fmt.Fprintf(stderr, "rut validate: detected column %d with confidence %.2f over %d records\n", d.Column, d.Confidence, d.Rows)
+			}
+		}
+		err = eachFile(stdin, fs.Args(), func(r io.Reader) error {
+			_, err := p.Each(r, emit)
+			return err
+		})
+	case "jsonl":
+		if *f.field == "" {
+			fmt.Fprintln(stderr, "rut validate: -field is required with -in jsonl")
+			return exitUsage
+		}
+		p := &rutjsonl.Processor{Field: *f.field}
+		err = eachFile(stdin, fs.Args(), func(r io.Reader) error {
+			_, err := p.Each(r, emit)
+			return err
+		})
+	default:
+		fmt.Fprintf(stderr, "rut validate: unknown input %q, expected lines, csv or jsonl\n", *f.in)
+		return exitUsage
+	}
+	if ferr := out.flush(); err == nil {
+		err = ferr
+	}
+	if err == nil && *f.reportpath != "" {
+		err = writeReport(stderr, *f.reportpath, &report)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "rut validate:", err)
+		return exitUsage
+	}
+
+	if report.Invalid > 0 {
+		return exitFail
+	}
+	return exitOK
+}
+
+// validateLines validates the arguments, or the non blank records of stdin
+// split by split
+func validateLines(stdin io.Reader, args []string, split bufio.SplitFunc, emit func(int, rut.Result) error) error {
+	if len(args) > 0 {
+		for i, input := range args {
+			if err := emit(i+1, rut.Check(input)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	sc := bufio.NewScanner(stdin)
+	sc.Split(split)
+	for line := 1; sc.Scan(); line++ {
+		if sc.Text() == "" {
+			continue
+		}
+		if err := emit(line, rut.Check(sc.Text())); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// eachFile calls fn with every named file, or stdin when there are none
+func eachFile(stdin io.Reader, names []string, fn func(io.Reader) error) error {
+	if len(names) == 0 {
+		return fn(stdin)
+	}
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = fn(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func writeReport(stderr io.Writer, path string, report *rut.Report) error {
+	w := stderr
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+type discard struct{}
+
+func (discard) write(int, rut.Result) error { return nil }
+func (discard) flush() error                { return nil }
@@ -0,0 +1,150 @@
+/*
+Command rutd serves the ruthttp API
+
+	rutd [-addr :8080] [-metrics=false] [-otel] [-verify-url url]
+
+Prometheus metrics are served on /metrics, see package rutprom. -otel
+exports OpenTelemetry traces and metrics over OTLP/HTTP as configured
+by the OTEL_EXPORTER_OTLP_* variables, see package rutotel.
+
+/healthz, /readyz and /version serve the orchestrator probes, with
+-verify-url /readyz also checks that the SII verification service
+answers, see package rutverify
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/ruthttp"
+	"github.com/alvarolm/rut/rutotel"
+	"github.com/alvarolm/rut/rutprom"
+	"github.com/alvarolm/rut/rutverify"
+)
+
+// version is answered by /version, set it with
+// -ldflags "-X main.version=v1.2.3", defaults to the module version
+var version string
+
+// probeRut is looked up by the /readyz verifier check, the rut of the SII
+const probeRut rut.Rut = "60803000-K"
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen `address`")
+	maxBatch := flag.Int("max-batch", ruthttp.DefaultMaxBatch, "maximum ruts per /validate or /format request")
+	maxGenerate := flag.Int("max-generate", ruthttp.DefaultMaxGenerate, "maximum ruts per /generate request")
+	workers := flag.Int("workers", 0, "batch validation workers, 0 uses GOMAXPROCS")
+	metrics := flag.Bool("metrics", true, "serve Prometheus metrics on /metrics")
+	otlp := flag.Bool("otel", false, "export OpenTelemetry traces and metrics over OTLP")
+	verifyURL := flag.String("verify-url", "", "SII verification service `url` checked by /readyz, empty skips the check")
+	flag.Parse()
+
+	opts := ruthttp.Options{
+		MaxBatch:    *maxBatch,
+		MaxGenerate: *maxGenerate,
+		Workers:     *workers,
+	}
+	mux := http.NewServeMux()
+	if *metrics {
+		m, err := rutprom.NewMetrics(prometheus.DefaultRegisterer)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.Observer = m
+		mux.Handle("/metrics", promhttp.Handler())
+	}
+	health := ruthttp.HealthOptions{Version: version}
+	if *verifyURL != "" {
+		health.Checks = map[string]func(context.Context) error{
+			"verifier": verifierCheck(&rutverify.SII{URL: *verifyURL}),
+		}
+	}
+	h := ruthttp.NewHealth(health)
+	for _, path := range []string{"/healthz", "/readyz", "/version"} {
+		mux.Handle(path, h)
+	}
+
+	var handler http.Handler
+	if *otlp {
+		shutdown, err := setupOTel(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer shutdown(context.Background())
+
+		tel, err := rutotel.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.Observer = observers{opts.Observer, tel}
+		handler = tel.Handler(ruthttp.NewServer(opts))
+	} else {
+		handler = ruthttp.NewServer(opts)
+	}
+	mux.Handle("/", handler)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	idle := make(chan struct{})
+	go func() {
+		defer close(idle)
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	log.Printf("rutd listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-idle
+}
+
+// observers is a ruthttp.Observer notifying every non nil one
+type observers []ruthttp.Observer
+
+func (obs observers) ObserveResult(res rut.Result) {
+	for _, o := range obs {
+		if o != nil {
+			o.ObserveResult(res)
+		}
+	}
+}
+
+func (obs observers) ObserveGenerated(n int) {
+	for _, o := range obs {
+		if o != nil {
+			o.ObserveGenerated(n)
+		}
+	}
+}
+
+// verifierCheck reports whether v answers, whatever the status of the
+// looked up rut
+func verifierCheck(v rutverify.Verifier) func(context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := v.Verify(ctx, probeRut)
+		return err
+	}
+}
@@ -0,0 +1,17 @@
+/*
+Command rutvet reports the ruts with a wrong 'digito verificador' hardcoded
+in string literals, it runs the rutvet analyzer under go vet
+
+	$ go vet -vettool=$(which rutvet) ./...
+*/
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/alvarolm/rut/rutvet"
+)
+
+func main() {
+	unitchecker.Main(rutvet.Analyzer)
+}
@@ -0,0 +1,16 @@
+//go:build js && wasm
+
+// Command rutwasm registers the wasm package bindings and waits forever,
+// build it with GOOS=js GOARCH=wasm
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/alvarolm/rut/wasm"
+)
+
+func main() {
+	wasm.Register(js.Global())
+	select {}
+}
@@ -0,0 +1,62 @@
+package rut
+
+import "testing"
+
+func TestUint32(t *testing.T) {
+	var prev uint32
+	for body := 1000000; body < 100000000; body += 9973 {
+		r := fromBody(body)
+		v, err := r.ToUint32()
+		if err != nil {
+			t.Fatal(r, err)
+		}
+		if v <= prev {
+			t.Fatal(r, "expected packed ruts to sort by 'cuerpo'", v, prev)
+		}
+		prev = v
+		if unpacked, err := FromUint32(v); err != nil || unpacked != r {
+			t.Fatal(v, "expected", r, "got", unpacked, err)
+		}
+	}
+
+	r := Rut("13.117.182-k")
+	if v, err := r.ToUint32(); err != nil || v != 13117182<<4|10 {
+		t.Error("unexpected packed rut", v, err)
+	}
+}
+
+func TestUint32Errors(t *testing.T) {
+	r := Rut("12345678-0")
+	if _, err := r.ToUint32(); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+
+	for _, v := range []uint32{12345678<<4 | 0, 12345678<<4 | 11, 12345678<<4 | 15} {
+		if _, err := FromUint32(v); err != ErrInvalidUint32 {
+			t.Error(v, "expected ErrInvalidUint32, got", err)
+		}
+	}
+	if _, err := FromUint32(1 << 31); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+	if _, err := FromUint32(0); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+
+	r = "123.456.789-2"
+	if _, err := r.ToUint32(); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+
+	defer func(max int) { MaxRutlength = max }(MaxRutlength)
+	MaxRutlength = NineDigitRutlength
+	if v, err := r.ToUint32(); err != nil {
+		t.Error("unexpected error", err)
+	} else if unpacked, err := FromUint32(v); err != nil || unpacked != "123456789-2" {
+		t.Error("unexpected rut", unpacked, err)
+	}
+	r = fromBody(uint32max + 1)
+	if _, err := r.ToUint32(); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+}
@@ -0,0 +1,42 @@
+package rut
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Dedup streams one rut per line from r and writes every distinct one to w
+// in its normalized form, in order of first appearance. blank lines are
+// ignored and invalid ones are skipped, both are accounted in the report.
+// seen 'cuerpos' are kept in a bitmap, memory is bounded by the 'cuerpo'
+// range (about 12MB for 8 digits) whatever the size of the input
+func Dedup(w io.Writer, r io.Reader) (report Report, err error) {
+	// a bufio.Reader has no line length limit, a huge line is one more
+	// invalid rut instead of the end of the input
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for {
+		var line string
+		line, err = br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		eof := err == io.EOF
+
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			duplicates := report.Duplicates
+			res := Check(line)
+			report.Add(res)
+			if res.Err == nil && report.Duplicates == duplicates {
+				if _, err = bw.WriteString(string(res.Rut) + "\n"); err != nil {
+					return
+				}
+			}
+		}
+
+		if eof {
+			return report, bw.Flush()
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package rut
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	in := strings.Join([]string{
+		"12.345.678-5",
+		"11111111-1",
+		"12345678-5",
+		"",
+		"12345678-0",
+		"13117182-k",
+		strings.Repeat("1", 100000),
+		"13.117.182-K",
+	}, "\r\n")
+
+	var out bytes.Buffer
+	report, err := Dedup(&out, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "12345678-5\n11111111-1\n13117182-K\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+	if report.Total != 7 || report.Valid != 5 || report.Duplicates != 2 || report.Errors["invalid_dv"] != 1 || report.Errors["max_length"] != 1 {
+		t.Error("unexpected report", report)
+	}
+}
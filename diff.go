@@ -0,0 +1,36 @@
+package rut
+
+import "iter"
+
+// Diff compares two collections of ruts by their normalized value,
+// dots, case and zero padding don't make a difference. invalid ruts are
+// ignored. results are distinct and sorted by 'cuerpo'
+func Diff(a, b iter.Seq[Rut]) (onlyA, onlyB, both []Rut) {
+	setA, setB := bodies(a), bodies(b)
+
+	for body := range setA.all() {
+		if setB.has(body) {
+			both = append(both, fromBody(int(body)))
+		} else {
+			onlyA = append(onlyA, fromBody(int(body)))
+		}
+	}
+	for body := range setB.all() {
+		if !setA.has(body) {
+			onlyB = append(onlyB, fromBody(int(body)))
+		}
+	}
+	return
+}
+
+// bodies collects the 'cuerpos' of the valid ruts of seq
+func bodies(seq iter.Seq[Rut]) *bodyset {
+	set := new(bodyset)
+	for r := range seq {
+		if _, err := r.Validate(); err != nil {
+			continue
+		}
+		set.set(r.body())
+	}
+	return set
+}
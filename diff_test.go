@@ -0,0 +1,23 @@
+package rut
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := []Rut{"12.345.678-5", "11111111-1", "5126663-3", "invalid"}
+	b := []Rut{"13117182-k", "12345678-5", "05.126.663-3"}
+
+	onlyA, onlyB, both := Diff(slices.Values(a), slices.Values(b))
+
+	if !slices.Equal(onlyA, []Rut{"11111111-1"}) {
+		t.Error("unexpected onlyA", onlyA)
+	}
+	if !slices.Equal(onlyB, []Rut{"13117182-K"}) {
+		t.Error("unexpected onlyB", onlyB)
+	}
+	if !slices.Equal(both, []Rut{"5126663-3", "12345678-5"}) {
+		t.Error("unexpected both", both)
+	}
+}
@@ -0,0 +1,40 @@
+package rut
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestComputeDVBatch(t *testing.T) {
+	bodies := []uint32{0, 1, 9, 10, 12345678, 13117182, 999999999, math.MaxUint32}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		bodies = append(bodies, rnd.Uint32())
+	}
+
+	dvs := ComputeDVBatch(bodies)
+	if len(dvs) != len(bodies) {
+		t.Fatal("expected", len(bodies), "got", len(dvs))
+	}
+	for i, body := range bodies {
+		if expected := byte(computeDV(int(body))); dvs[i] != expected {
+			t.Fatalf("%d: expected %c, got %c", body, expected, dvs[i])
+		}
+	}
+
+	if dvs := ComputeDVBatch(nil); len(dvs) != 0 {
+		t.Error("expected no dvs, got", dvs)
+	}
+}
+
+func BenchmarkComputeDVBatch(b *testing.B) {
+	bodies := make([]uint32, 4096)
+	for i := range bodies {
+		bodies[i] = uint32(10000000 + i*997)
+	}
+	b.SetBytes(int64(len(bodies)))
+	for i := 0; i < b.N; i++ {
+		ComputeDVBatch(bodies)
+	}
+}
@@ -0,0 +1,43 @@
+package rut
+
+// MarshalBinary implements encoding.BinaryMarshaler, used by gob and
+// msgpack among others, with the normalized form of the rut. the zero
+// value marshals as is, invalid ruts fail.
+//
+// it changed the wire format of Rut: gob sends it as an encoder value
+// instead of a string, so the gob streams written before it fail to
+// decode into a Rut, and the new ones into a string, with "gob: wrong
+// type". msgpack writes it as bin instead of str. drain the queues
+// before upgrading, or decode the old messages into a string field and
+// Parse it
+func (r Rut) MarshalBinary() ([]byte, error) {
+	if r == "" {
+		return nil, nil
+	}
+	if _, err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(r), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, data is
+// validated and normalized
+func (r *Rut) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*r = ""
+		return nil
+	}
+	p, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*r = p
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, used by encoding/json,
+// flag and most config and env libraries, with the rules of UnmarshalBinary:
+// the decoded rut is normalized, not kept as written
+func (r *Rut) UnmarshalText(text []byte) error {
+	return r.UnmarshalBinary(text)
+}
@@ -0,0 +1,60 @@
+package rut
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+type job struct {
+	ID     int
+	Client Rut
+	Ref    *Rut
+	Empty  Rut
+}
+
+func TestGob(t *testing.T) {
+	ref := Rut("13.117.182-k")
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(job{ID: 1, Client: "12.345.678-5", Ref: &ref}); err != nil {
+		t.Fatal(err)
+	}
+	if ref != "13.117.182-k" {
+		t.Error("the encoded rut was modified", ref)
+	}
+
+	var j job
+	if err := gob.NewDecoder(&buf).Decode(&j); err != nil {
+		t.Fatal(err)
+	}
+	if j.Client != "12345678-5" || j.Ref == nil || *j.Ref != "13117182-K" || j.Empty != "" {
+		t.Error("unexpected job", j)
+	}
+
+	buf.Reset()
+	if err := gob.NewEncoder(&buf).Encode(job{Client: "12345678-0"}); err == nil {
+		t.Error("expected an error encoding an invalid rut")
+	}
+
+	// the streams of the versions encoding Rut as a string, see MarshalBinary
+	buf.Reset()
+	if err := gob.NewEncoder(&buf).Encode(struct{ Client string }{"12.345.678-5"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(&j); err == nil {
+		t.Error("expected the string encoded rut to fail")
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	var r Rut
+	if err := r.UnmarshalBinary([]byte("12345678-0")); err != ErrinvalidDV || r != "" {
+		t.Error("expected ErrinvalidDV, got", r, err)
+	}
+	if err := r.UnmarshalBinary([]byte("12.345.678-5")); err != nil || r != "12345678-5" {
+		t.Error("unexpected rut", r, err)
+	}
+	if b, err := Rut("").MarshalBinary(); err != nil || len(b) != 0 {
+		t.Error("unexpected zero value encoding", b, err)
+	}
+}
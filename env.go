@@ -0,0 +1,22 @@
+package rut
+
+import (
+	"fmt"
+	"os"
+)
+
+var ErrEnvUnset = NewError("env_unset", "environment variable not set")
+
+// ParseEnv parses the rut of the environment variable key, the errors
+// name the variable, eg. "OWNER_RUT: invalid 'digito verificador'"
+func ParseEnv(key string) (Rut, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("%s: %w", key, ErrEnvUnset)
+	}
+	r, err := Parse(v)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package rut
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Setenv("TEST_OWNER_RUT", "76.086.428-5")
+	if r, err := ParseEnv("TEST_OWNER_RUT"); err != nil || r != "76086428-5" {
+		t.Error("unexpected rut", r, err)
+	}
+
+	t.Setenv("TEST_OWNER_RUT", "76.086.428-0")
+	_, err := ParseEnv("TEST_OWNER_RUT")
+	if !errors.Is(err, ErrinvalidDV) || err.Error() != "TEST_OWNER_RUT: invalid 'digito verificador'" {
+		t.Error("unexpected error", err)
+	}
+
+	if _, err := ParseEnv("TEST_UNSET_RUT"); !errors.Is(err, ErrEnvUnset) || Code(err) != "env_unset" {
+		t.Error("expected ErrEnvUnset, got", err)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var config struct {
+		Owner Rut  `json:"owner"`
+		Ref   *Rut `json:"ref"`
+		Empty Rut  `json:"empty"`
+	}
+	if err := json.Unmarshal([]byte(`{"owner":"12.345.678-5","ref":"13117182-k","empty":""}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Owner != "12345678-5" || config.Ref == nil || *config.Ref != "13117182-K" || config.Empty != "" {
+		t.Error("unexpected config", config)
+	}
+
+	if err := json.Unmarshal([]byte(`{"owner":"12.345.678-0"}`), &config); !errors.Is(err, ErrinvalidDV) {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
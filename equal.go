@@ -0,0 +1,27 @@
+package rut
+
+import "crypto/subtle"
+
+// EqualConstantTime reports whether a and b are the same valid rut, the
+// comparison of their Keys takes a time independent of their
+// contents, meant for ruts that are part of an authentication factor.
+// normalization isn't constant time and invalid ruts are never equal
+func EqualConstantTime(a, b Rut) bool {
+	na, nb := a.Key(), b.Key()
+	if na == "" || nb == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare(padded(Rut(na)), padded(Rut(nb))) == 1
+}
+
+// padded zero pads a normalized rut to MaxRutlength so the compared
+// slices have the same length and ConstantTimeCompare doesn't return early
+func padded(r Rut) []byte {
+	n := MaxRutlength
+	if len(r) > n {
+		n = len(r)
+	}
+	b := make([]byte, n)
+	copy(b[n-len(r):], r)
+	return b
+}
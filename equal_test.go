@@ -0,0 +1,24 @@
+package rut
+
+import "testing"
+
+func TestEqualConstantTime(t *testing.T) {
+	for _, c := range []struct {
+		a, b  Rut
+		equal bool
+	}{
+		{"13117182-K", "13.117.182-k", true},
+		{"5126663-3", "05.126.663-3", true},
+		{"5126663-3", "13117182-K", false},
+		{"11111111-1", "1111111-4", false},
+		{"12345678-0", "12345678-0", false},
+		{"", "", false},
+	} {
+		if got := EqualConstantTime(c.a, c.b); got != c.equal {
+			t.Error(c.a, c.b, "expected", c.equal, "got", got)
+		}
+		if got := EqualConstantTime(c.b, c.a); got != c.equal {
+			t.Error(c.b, c.a, "expected", c.equal, "got", got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package rut
+
+import "errors"
+
+var ErrUnknownEra = errors.New("'cuerpo' out of the era table ranges")
+
+// EraSegment maps the 'cuerpos' in [Min, Max) linearly to the issuance
+// years [FromYear, ToYear], Spread is the uncertainty in years of the
+// estimate, either way
+type EraSegment struct {
+	Min, Max         int
+	FromYear, ToYear int
+	Spread           int
+}
+
+// EraTable lists the segments of EstimateEra, the 'cuerpos' not in any
+// segment have no estimate
+type EraTable []EraSegment
+
+// DefaultEraTable is a rough fit of the public ruts, a person's
+// 'cuerpo' is usually issued at birth, but the ones issued late, eg. to
+// immigrants, make the estimate only good for dataset wide sanity checks
+var DefaultEraTable = EraTable{
+	// persons
+	{Min: 1000000, Max: 5000000, FromYear: 1900, ToYear: 1945, Spread: 10},
+	{Min: 5000000, Max: 10000000, FromYear: 1945, ToYear: 1965, Spread: 6},
+	{Min: 10000000, Max: 15000000, FromYear: 1965, ToYear: 1982, Spread: 4},
+	{Min: 15000000, Max: 20000000, FromYear: 1982, ToYear: 1999, Spread: 3},
+	{Min: 20000000, Max: 25000000, FromYear: 1999, ToYear: 2014, Spread: 3},
+	{Min: 25000000, Max: 28000000, FromYear: 2014, ToYear: 2024, Spread: 4},
+
+	// companies
+	{Min: 76000000, Max: 77000000, FromYear: 2002, ToYear: 2019, Spread: 3},
+	{Min: 77000000, Max: 78000000, FromYear: 2019, ToYear: 2023, Spread: 2},
+	{Min: 78000000, Max: 79000000, FromYear: 2023, ToYear: 2026, Spread: 2},
+	{Min: 96000000, Max: 100000000, FromYear: 1985, ToYear: 2002, Spread: 5},
+}
+
+// Era is an estimated issuance year and its range
+type Era struct {
+	Year     int
+	From, To int
+}
+
+// Decade returns the decade of Year, eg. 1980
+func (e Era) Decade() int {
+	return e.Year - e.Year%10
+}
+
+// EstimateEra validates the rut and estimates its issuance year
+// with DefaultEraTable
+func (r *Rut) EstimateEra() (Era, error) {
+	return DefaultEraTable.Estimate(r)
+}
+
+// Estimate validates r and estimates its issuance year, it fails with
+// ErrUnknownEra when no segment has its 'cuerpo'
+func (t EraTable) Estimate(r *Rut) (era Era, err error) {
+	body, err := r.Body()
+	if err != nil {
+		return
+	}
+	for _, s := range t {
+		if body < s.Min || body >= s.Max {
+			continue
+		}
+		era.Year = s.FromYear + (s.ToYear-s.FromYear)*(body-s.Min)/(s.Max-s.Min)
+		era.From, era.To = era.Year-s.Spread, era.Year+s.Spread
+		return
+	}
+	return era, ErrUnknownEra
+}
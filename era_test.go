@@ -0,0 +1,45 @@
+package rut
+
+import "testing"
+
+func TestEstimateEra(t *testing.T) {
+	r := fromBody(17500000)
+	era, err := r.EstimateEra()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if era.Year != 1990 || era.From != 1987 || era.To != 1993 || era.Decade() != 1990 {
+		t.Error("unexpected era", era)
+	}
+
+	// later 'cuerpos', later eras
+	prev := 0
+	for _, body := range []int{3000000, 8000000, 12000000, 18000000, 23000000, 26000000} {
+		r := fromBody(body)
+		era, err := r.EstimateEra()
+		if err != nil {
+			t.Fatal(body, err)
+		}
+		if era.Year <= prev || era.From > era.Year || era.To < era.Year {
+			t.Error(body, "unexpected era", era)
+		}
+		prev = era.Year
+	}
+
+	r = fromBody(50000000)
+	if _, err := r.EstimateEra(); err != ErrUnknownEra {
+		t.Error("expected ErrUnknownEra, got", err)
+	}
+	r = "12345678-0"
+	if _, err := r.EstimateEra(); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
+
+func TestEraTable(t *testing.T) {
+	table := EraTable{{Min: 1000000, Max: 2000000, FromYear: 2000, ToYear: 2010, Spread: 1}}
+	r := fromBody(1500000)
+	if era, err := table.Estimate(&r); err != nil || era != (Era{Year: 2005, From: 2004, To: 2006}) {
+		t.Error("unexpected era", era, err)
+	}
+}
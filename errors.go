@@ -0,0 +1,159 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+// Error is the type of the validation errors, Code is a stable
+// identifier suitable for metrics, logs and API responses
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError returns an *Error, packages building on rut use it so their
+// own validation errors get a Code too
+func NewError(code, message string) error {
+	return &Error{Code: code, Message: message}
+}
+
+// InputError locates the cause of a validation error in the input,
+// errors.Is and Code see through it to the package error
+type InputError struct {
+	// Err is the package error, eg. ErrExpectedDigit
+	Err error
+
+	// Index is the byte offset in the input of Char, the offending
+	// character, -1 when the error has no position
+	Index int
+	Char  rune
+
+	// Input, if set, is the shape of the input or the input itself
+	// sanitized for logs, see Validator.EchoInput and MaxEchoLength
+	Input string
+}
+
+func (e *InputError) Error() string {
+	msg := e.Err.Error()
+	if e.Index >= 0 {
+		msg += ": " + strconv.QuoteRune(e.Char) + " at index " + strconv.Itoa(e.Index)
+	}
+	if e.Input != "" {
+		msg += ` in "` + e.Input + `"`
+	}
+	return msg
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// MaxEchoLength is the number of characters of the input an InputError
+// echoes, the longer inputs are truncated and end in "..."
+const MaxEchoLength = 32
+
+// echo returns the first MaxEchoLength characters of input with the
+// control, non printable and backslash characters escaped as in Go
+// literals, so it's safe to log and the same inputs give the same echo.
+// unless raw the digits are replaced by '9' and the letters by 'a'
+func echo(input string, raw bool) string {
+	var sb strings.Builder
+	var n int
+	for _, c := range input {
+		if n++; n > MaxEchoLength {
+			sb.WriteString("...")
+			break
+		}
+		switch {
+		case raw:
+		case unicode.IsDigit(c):
+			c = '9'
+		case unicode.IsLetter(c):
+			c = 'a'
+		}
+		if unicode.IsPrint(c) && c != '\\' && c != '"' {
+			sb.WriteRune(c)
+			continue
+		}
+		q := strconv.Quote(string(c))
+		sb.WriteString(q[1 : len(q)-1])
+	}
+	return sb.String()
+}
+
+// expectedDigitError returns the *InputError of an input failing as
+// ErrExpectedDigit, offset is added to the index of the character
+func expectedDigitError(input string, offset int) error {
+	// the 'cuerpo' is everything but the last two characters other than '.'
+	end := len(input)
+	for kept := 0; end > 0 && kept < 2; {
+		if end--; input[end] != '.' {
+			kept++
+		}
+	}
+	for i := 0; i < end; i++ {
+		if c := input[i]; c != '.' && (c < '0' || c > '9') {
+			char, _ := utf8.DecodeRuneInString(input[i:])
+			return &InputError{Err: ErrExpectedDigit, Index: offset + i, Char: char}
+		}
+	}
+	return ErrExpectedDigit
+}
+
+// Codes of the errors that aren't an *Error
+const (
+	CodeCanceled         = "canceled"
+	CodeDeadlineExceeded = "deadline_exceeded"
+	CodeUnknown          = "unknown"
+)
+
+// Code returns the Code of the *Error in err's chain,
+// "" for nil and CodeUnknown for errors without one
+func Code(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		return e.Code
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	default:
+		return CodeUnknown
+	}
+}
+
+// statusError returns the error of a rutcore status, nil for rutcore.OK
+func statusError(st rutcore.Status) error {
+	switch st {
+	case rutcore.OK:
+		return nil
+	case rutcore.ErrMinLength:
+		return ErrMinLength
+	case rutcore.ErrMaxLength:
+		return ErrMaxLength
+	case rutcore.ErrNoDVSeparator:
+		return ErrNoDVSeparator
+	case rutcore.ErrInvalidDVchar:
+		return ErrInvalidDVchar
+	case rutcore.ErrExpectedDigit:
+		return ErrExpectedDigit
+	default:
+		return ErrinvalidDV
+	}
+}
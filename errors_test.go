@@ -0,0 +1,91 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	for err, expected := range map[error]string{
+		nil:                                   "",
+		ErrinvalidDV:                          "invalid_dv",
+		fmt.Errorf("row 3: %w", ErrMinLength): "min_length",
+		context.Canceled:                      CodeCanceled,
+		errors.New("other"):                   CodeUnknown,
+	} {
+		if got := Code(err); got != expected {
+			t.Error(err, "expected", expected, "got", got)
+		}
+	}
+}
+
+func TestInputError(t *testing.T) {
+	v := Validator{DetailedErrors: true}
+	for _, tc := range []struct {
+		input    string
+		index    int
+		char     rune
+		expected string
+	}{
+		{"12.3a5.678-5", 4, 'a', `expected digit in 'cuerpo', instead found invalid character: 'a' at index 4`},
+		{"12345\n78-5", 5, '\n', `expected digit in 'cuerpo', instead found invalid character: '\n' at index 5`},
+		{"1234ñ67-5", 4, 'ñ', `expected digit in 'cuerpo', instead found invalid character: 'ñ' at index 4`},
+		{"123-567-5.", 3, '-', `expected digit in 'cuerpo', instead found invalid character: '-' at index 3`},
+	} {
+		_, err := v.Validate(tc.input)
+		var ie *InputError
+		if !errors.As(err, &ie) || ie.Index != tc.index || ie.Char != tc.char || err.Error() != tc.expected {
+			t.Errorf("%q: unexpected error %v", tc.input, err)
+		}
+		if !errors.Is(err, ErrExpectedDigit) || Code(err) != "expected_digit" {
+			t.Errorf("%q: expected to match ErrExpectedDigit, got %v", tc.input, err)
+		}
+	}
+
+	v.TrimSpace = true
+	if _, err := v.Validate("  12.3a5.678-5"); err.(*InputError).Index != 6 {
+		t.Error("expected the index in the untrimmed input, got", err)
+	}
+
+	if _, err := (&Validator{}).Validate("12.3a5.678-5"); err != ErrExpectedDigit {
+		t.Error("expected", ErrExpectedDigit, "got", err)
+	}
+}
+
+func TestEchoInput(t *testing.T) {
+	v := Validator{EchoInput: true}
+	for input, expected := range map[string]string{
+		"12345678-0":                   `invalid 'digito verificador' in "99999999-9"`,
+		"1\n2\x1b-\"\\":                `length less than expected in "9\n9\x1b-\"\\"`,
+		strings.Repeat("9", 40) + "-5": `exceeded max length in "` + strings.Repeat("9", 32) + `..."`,
+		"12-\u202e":                    `length less than expected in "99-\u202e"`,
+		"12.345.678-k":                 `invalid 'digito verificador' in "99.999.999-a"`,
+	} {
+		_, err := v.Validate(input)
+		var ie *InputError
+		if !errors.As(err, &ie) || ie.Index != -1 || err.Error() != expected {
+			t.Errorf("%q: expected %q, got %v", input, expected, err)
+		}
+	}
+
+	raw := Validator{EchoRawInput: true}
+	if _, err := raw.Validate("12345678-0"); err == nil || err.Error() != `invalid 'digito verificador' in "12345678-0"` {
+		t.Error("unexpected error", err)
+	}
+
+	v.DetailedErrors = true
+	_, err := v.Validate("12.3a5.678-5")
+	if expected := `expected digit in 'cuerpo', instead found invalid character: 'a' at index 4 in "99.9a9.999-9"`; err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+	if !errors.Is(err, ErrExpectedDigit) {
+		t.Error("expected to match ErrExpectedDigit, got", err)
+	}
+
+	if _, err := v.Validate("12345678-5"); err != nil {
+		t.Error("unexpected error", err)
+	}
+}
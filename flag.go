@@ -0,0 +1,23 @@
+package rut
+
+import "fmt"
+
+// Set validates s and stores its normalized form, with String it makes
+// *Rut a flag.Value, and with Type a pflag.Value for cobra commands
+//
+//	var owner rut.Rut
+//	flag.Var(&owner, "owner", "owner rut")
+//	cmd.Flags().Var(&owner, "owner", "owner rut")
+func (r *Rut) Set(s string) error {
+	p, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("%w, expected a rut like 12.345.678-5 or 12345678-5", err)
+	}
+	*r = p
+	return nil
+}
+
+// Type returns the name of the flag value type, "rut"
+func (r *Rut) Type() string {
+	return "rut"
+}
@@ -0,0 +1,34 @@
+package rut
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	var owner Rut
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&owner, "owner", "owner rut")
+
+	if err := fs.Parse([]string{"-owner", "13.117.182-k"}); err != nil || owner != "13117182-K" {
+		t.Error("unexpected owner", owner, err)
+	}
+	if owner.Type() != "rut" {
+		t.Error("unexpected type", owner.Type())
+	}
+
+	err := fs.Parse([]string{"-owner", "12345678-0"})
+	if err == nil || !strings.Contains(err.Error(), "invalid 'digito verificador', expected a rut like") {
+		t.Error("unexpected error", err)
+	}
+	if owner != "13117182-K" {
+		t.Error("a failed Set modified the rut", owner)
+	}
+
+	if err := owner.Set("1-9"); Code(err) != "min_length" {
+		t.Error("expected min_length, got", err)
+	}
+}
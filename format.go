@@ -0,0 +1,214 @@
+package rut
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+var ErrUnknownStyle = errors.New("unknown format style")
+
+// Style selects how Format renders a rut
+type Style int
+
+const (
+	// StyleCanonical is the normalized form, '12345678-5'
+	StyleCanonical Style = iota
+
+	// StyleDotted groups the 'cuerpo' thousands, '12.345.678-5'
+	StyleDotted
+
+	// StyleFixedWidth zero pads the 'cuerpo' to MaxRutlength, '05126663-3'
+	StyleFixedWidth
+
+	// StyleNumeric is the 'cuerpo' alone, '12345678',
+	// the 'digito verificador' is implied by it
+	StyleNumeric
+
+	// StyleMasked hides the leading 'cuerpo' digits, '**.***.678-5'
+	StyleMasked
+)
+
+var stylenames = [...]string{
+	StyleCanonical:  "canonical",
+	StyleDotted:     "dotted",
+	StyleFixedWidth: "fixed-width",
+	StyleNumeric:    "numeric",
+	StyleMasked:     "masked",
+}
+
+func (s Style) String() string {
+	if s < 0 || int(s) >= len(stylenames) {
+		return "Style(" + strconv.Itoa(int(s)) + ")"
+	}
+	return stylenames[s]
+}
+
+// ParseStyle parses the String form of a Style, "plain" is accepted for StyleCanonical
+func ParseStyle(s string) (Style, error) {
+	if s == "plain" {
+		return StyleCanonical, nil
+	}
+	for style, name := range stylenames {
+		if s == name {
+			return Style(style), nil
+		}
+	}
+	return StyleCanonical, ErrUnknownStyle
+}
+
+// DefaultGroupSeparator is the FormatOptions.GroupSeparator used when unset
+const DefaultGroupSeparator = "."
+
+// FormatOptions configures Format
+type FormatOptions struct {
+	Style Style
+
+	// GroupSeparator groups the 'cuerpo' thousands of StyleDotted and
+	// StyleMasked, eg. "," for '12,345,678-5' or " " for '12 345 678-5'.
+	// defaults to DefaultGroupSeparator
+	GroupSeparator string
+
+	// PreserveDVCase keeps a lowercase 'k' as 'digito verificador'
+	// instead of rewriting it to 'K', for the case-sensitive systems
+	// storing '13117182-k'
+	PreserveDVCase bool
+}
+
+// Format validates the rut and renders it in the opts style
+func (r *Rut) Format(opts FormatOptions) (string, error) {
+	lower := opts.PreserveDVCase && r != nil && strings.HasSuffix(string(*r), "k")
+	if _, err := r.validate(MinRutlength, renderLength()); err != nil {
+		return "", err
+	}
+
+	dv := (*r)[len(*r)-1]
+	if lower {
+		dv = 'k'
+	}
+	out, err := appendStyle(make([]byte, 0, len(*r)+4), r.body(), dv, opts)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// WriteFormatted validates the rut and writes it to w in the opts style
+// without allocating, for the report generators streaming every row to
+// files or sockets. unlike Format the rut is left unmodified
+func (r *Rut) WriteFormatted(w io.Writer, opts FormatOptions) (int, error) {
+	if r == nil {
+		return 0, ErrMinLength
+	}
+	body, dv, st := rutcore.Check(string(*r), MinRutlength, renderLength())
+	if st != rutcore.OK {
+		return 0, statusError(st)
+	}
+	if opts.PreserveDVCase && strings.HasSuffix(string(*r), "k") {
+		dv = 'k'
+	}
+
+	buf := formatbufs.Get().(*[]byte)
+	defer formatbufs.Put(buf)
+	out, err := appendStyle((*buf)[:0], body, dv, opts)
+	if err != nil {
+		return 0, err
+	}
+	*buf = out
+	return w.Write(out)
+}
+
+// Formats is a rut in every Style, see AllFormats
+type Formats struct {
+	Canonical  string `json:"canonical"`
+	Dotted     string `json:"dotted"`
+	FixedWidth string `json:"fixed_width"`
+	Numeric    string `json:"numeric"`
+	Masked     string `json:"masked"`
+}
+
+// AllFormats validates the rut once and renders it in every Style with
+// the default FormatOptions, the rut is left unmodified
+func (r *Rut) AllFormats() (f Formats, err error) {
+	if r == nil {
+		return f, ErrMinLength
+	}
+	body, dv, st := rutcore.Check(string(*r), MinRutlength, renderLength())
+	if st != rutcore.OK {
+		return f, statusError(st)
+	}
+
+	// every rendering is a slice of a single string
+	var buf [80]byte
+	b := buf[:0]
+	var ends [len(stylenames)]int
+	for style := range stylenames {
+		b, _ = appendStyle(b, body, dv, FormatOptions{Style: Style(style)})
+		ends[style] = len(b)
+	}
+	all := string(b)
+	at := func(style Style) string {
+		start := 0
+		if style > 0 {
+			start = ends[style-1]
+		}
+		return all[start:ends[style]]
+	}
+	return Formats{
+		Canonical:  at(StyleCanonical),
+		Dotted:     at(StyleDotted),
+		FixedWidth: at(StyleFixedWidth),
+		Numeric:    at(StyleNumeric),
+		Masked:     at(StyleMasked),
+	}, nil
+}
+
+var formatbufs = sync.Pool{New: func() any {
+	buf := make([]byte, 0, 32)
+	return &buf
+}}
+
+// appendStyle appends the rut of a valid 'cuerpo' and 'digito verificador'
+// rendered in the opts style
+func appendStyle(dst []byte, body uint64, dv byte, opts FormatOptions) ([]byte, error) {
+	sep := opts.GroupSeparator
+	if sep == "" {
+		sep = DefaultGroupSeparator
+	}
+
+	var buf [20]byte
+	digits := strconv.AppendUint(buf[:0], body, 10)
+	switch opts.Style {
+	case StyleCanonical:
+		dst = append(dst, digits...)
+	case StyleDotted:
+		dst = appendThousands(dst, digits, sep)
+	case StyleFixedWidth:
+		for pad := MaxRutlength - 2 - len(digits); pad > 0; pad-- {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits...)
+	case StyleNumeric:
+		return append(dst, digits...), nil
+	case StyleMasked:
+		for i := 0; i < len(digits)-3; i++ {
+			digits[i] = '*'
+		}
+		dst = appendThousands(dst, digits, sep)
+	default:
+		return dst, ErrUnknownStyle
+	}
+	return append(dst, dvseparator, dv), nil
+}
+
+// lowerDV rewrites a trailing 'K' to 'k'
+func lowerDV(s string) string {
+	if strings.HasSuffix(s, "K") {
+		return s[:len(s)-1] + "k"
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package rut
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		in       Rut
+		style    Style
+		expected string
+	}{
+		{"12.345.678-5", StyleCanonical, "12345678-5"},
+		{"12345678-5", StyleDotted, "12.345.678-5"},
+		{"5.126.663-3", StyleFixedWidth, "05126663-3"},
+		{"13117182-k", StyleFixedWidth, "13117182-K"},
+		{"13.117.182-k", StyleNumeric, "13117182"},
+		{"13117182-k", StyleMasked, "**.***.182-K"},
+	} {
+		got, err := tc.in.Format(FormatOptions{Style: tc.style})
+		if err != nil || got != tc.expected {
+			t.Error(tc.in, tc.style, "expected", tc.expected, "got", got, err)
+		}
+	}
+
+	invalid := Rut("12345678-0")
+	if _, err := invalid.Format(FormatOptions{}); err != ErrinvalidDV {
+		t.Error("expected", ErrinvalidDV, "got", err)
+	}
+
+	for _, s := range []Style{StyleCanonical, StyleDotted, StyleFixedWidth, StyleNumeric, StyleMasked} {
+		if parsed, err := ParseStyle(s.String()); err != nil || parsed != s {
+			t.Error(s, "round trip failed", parsed, err)
+		}
+	}
+	if s, err := ParseStyle("plain"); err != nil || s != StyleCanonical {
+		t.Error("expected plain to be canonical, got", s, err)
+	}
+}
+
+func TestFormatGroupSeparator(t *testing.T) {
+	for sep, expected := range map[string][2]string{
+		"":       {"12.345.678-5", "**.***.678-5"},
+		",":      {"12,345,678-5", "**,***,678-5"},
+		" ":      {"12 345 678-5", "** *** 678-5"},
+		"\u202f": {"12\u202f345\u202f678-5", "**\u202f***\u202f678-5"},
+	} {
+		for i, style := range []Style{StyleDotted, StyleMasked} {
+			r := Rut("12.345.678-5")
+			got, err := r.Format(FormatOptions{Style: style, GroupSeparator: sep})
+			if err != nil || got != expected[i] {
+				t.Errorf("%q %s: expected %q, got %q %v", sep, style, expected[i], got, err)
+			}
+		}
+	}
+
+	r := Rut("12345678-5")
+	if got, _ := r.Format(FormatOptions{Style: StyleCanonical, GroupSeparator: ","}); got != "12345678-5" {
+		t.Error("the separator only applies to grouped styles, got", got)
+	}
+}
+
+func TestFormatPreserveDVCase(t *testing.T) {
+	for _, tc := range []struct {
+		in       Rut
+		style    Style
+		expected string
+	}{
+		{"13.117.182-k", StyleCanonical, "13117182-k"},
+		{"13117182-k", StyleDotted, "13.117.182-k"},
+		{"13117182-K", StyleDotted, "13.117.182-K"},
+		{"13117182-k", StyleMasked, "**.***.182-k"},
+	} {
+		got, err := tc.in.Format(FormatOptions{Style: tc.style, PreserveDVCase: true})
+		if err != nil || got != tc.expected {
+			t.Error(tc.in, tc.style, "expected", tc.expected, "got", got, err)
+		}
+	}
+}
+
+func TestWriteFormatted(t *testing.T) {
+	styles := []Style{StyleCanonical, StyleDotted, StyleFixedWidth, StyleNumeric, StyleMasked}
+	for _, in := range []string{"12.345.678-5", "05.126.663-3", "13117182-k", "12345678-0", "1-9", "123-4"} {
+		for _, style := range styles {
+			for _, opts := range []FormatOptions{{Style: style}, {Style: style, GroupSeparator: " ", PreserveDVCase: true}} {
+				r := Rut(in)
+				var b strings.Builder
+				n, err := r.WriteFormatted(&b, opts)
+				if r != Rut(in) {
+					t.Fatal(in, "the rut was modified", r)
+				}
+				expected, experr := r.Format(opts)
+				if err != experr || b.String() != expected || n != len(expected) {
+					t.Errorf("%s %v: expected %q %v, got %q %v", in, opts, expected, experr, b.String(), err)
+				}
+			}
+		}
+	}
+
+	var r *Rut
+	if _, err := r.WriteFormatted(io.Discard, FormatOptions{}); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+
+	valid := Rut("12345678-5")
+	if allocs := testing.AllocsPerRun(100, func() { valid.WriteFormatted(io.Discard, FormatOptions{Style: StyleDotted}) }); allocs != 0 {
+		t.Error("expected no allocations, got", allocs)
+	}
+}
+
+func TestAllFormats(t *testing.T) {
+	r := Rut("013.117.182-k")
+	f, err := r.AllFormats()
+	expected := Formats{
+		Canonical:  "13117182-K",
+		Dotted:     "13.117.182-K",
+		FixedWidth: "13117182-K",
+		Numeric:    "13117182",
+		Masked:     "**.***.182-K",
+	}
+	if err != nil || f != expected || r != "013.117.182-k" {
+		t.Errorf("expected %+v, got %+v %v", expected, f, err)
+	}
+
+	r = "5126663-3"
+	if f, _ := r.AllFormats(); f.FixedWidth != "05126663-3" || f.Dotted != "5.126.663-3" {
+		t.Errorf("unexpected formats %+v", f)
+	}
+
+	r = "12345678-0"
+	if f, err := r.AllFormats(); err != ErrinvalidDV || f != (Formats{}) {
+		t.Error("expected", ErrinvalidDV, "got", f, err)
+	}
+	r = "12345678-5"
+	if allocs := testing.AllocsPerRun(100, func() { r.AllFormats() }); allocs != 1 {
+		t.Error("expected a single allocation, got", allocs)
+	}
+}
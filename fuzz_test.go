@@ -0,0 +1,97 @@
+package rut
+
+import (
+	"strings"
+	"testing"
+)
+
+var fuzzseeds = []string{
+	"",
+	"-",
+	"1",
+	"1-",
+	"-1",
+	"K",
+	"11111111-1",
+	"11.111.111-1",
+	"12345678-5",
+	"12.345.678-5",
+	"12345678-k",
+	"12345678-K",
+	"5126663-3",
+	"123456785",
+	"1234567-",
+	"12345678--5",
+	"12.345.678–5",
+	"１２３４５６７８-5",
+	"12345678-５",
+	"ñ1234567-5",
+	"+1234567-4",
+	"-1234567-4",
+	"01234567-4",
+	"0000000-0",
+	"00000000-0",
+	"99999999-9",
+	"100000000-2",
+	"........",
+	"........-1",
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, seed := range fuzzseeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		rut := Rut(s)
+		ai, err := rut.Validate()
+		if err == nil && ai == nil {
+			t.Fatal("nil validation info on success", s)
+		}
+		if err == nil && rune(rut[len(rut)-1]) != ai.ExpectedDV {
+			t.Fatal("valid rut with unexpected dv", s)
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzseeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		rut, err := Parse(s)
+		if err != nil {
+			if rut != "" {
+				t.Fatal("non empty rut on error", s)
+			}
+			return
+		}
+		if strings.ContainsRune(string(rut), '.') || strings.ContainsRune(string(rut), 'k') {
+			t.Fatal("not normalized", s, rut)
+		}
+		again, err := Parse(string(rut))
+		if err != nil || again != rut {
+			t.Fatal("normalized form does not parse to itself", s, rut, again, err)
+		}
+	})
+}
+
+func FuzzFormatRoundTrip(f *testing.F) {
+	for _, seed := range fuzzseeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		rut, err := Parse(s)
+		if err != nil {
+			return
+		}
+		decimal := rut.DecimalFormat()
+		back, err := Parse(decimal)
+		if err != nil {
+			t.Fatal("decimal format does not parse", s, decimal, err)
+		}
+		if back != rut {
+			t.Fatal("round trip mismatch", s, rut, back)
+		}
+		rut.MaskedFormat()
+	})
+}
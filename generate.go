@@ -0,0 +1,260 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/alvarolm/rut/internal/bitmap"
+)
+
+var (
+	ErrInvalidRange = errors.New("min must not be negative and max must be greater than min")
+	ErrNoCallback   = errors.New("no callback to receive the generated ruts")
+	ErrExhausted    = errors.New("every unique rut in range was generated")
+	ErrInvalidRate  = errors.New("rate, burst and jitter must not be negative")
+)
+
+// Default ranges of the generated 'cuerpos' when GenerateOptions sets none
+var (
+	DefaultPersonRange  = [2]int{5000000, 23000000}
+	DefaultCompanyRange = [2]int{76000000, 78000000}
+)
+
+// DefaultProgressEvery is the GenerateOptions.ProgressEvery used when unset
+const DefaultProgressEvery = 100000
+
+// GenerateOptions configures GenerateN and NewGenerator
+type GenerateOptions struct {
+	// Min and Max bound the generated 'cuerpos' to [Min, Max),
+	// when both are 0 the default range of Kind is used
+	Min, Max int
+
+	// Kind restricts the range to person or company 'cuerpos'
+	Kind Kind
+
+	// Unique never generates the same rut twice
+	Unique bool
+
+	// Rand is the source of the generated 'cuerpos', a seeded source
+	// makes the output reproducible. defaults to the global math/rand source
+	Rand *rand.Rand
+
+	// Each receives every generated rut in order,
+	// returning an error stops the generation and GenerateN returns it
+	Each func(Rut) error
+
+	// Progress, if set, is called every ProgressEvery generated ruts
+	// and once more when the generation stops
+	Progress func(done, total int)
+
+	// ProgressEvery defaults to DefaultProgressEvery
+	ProgressEvery int
+
+	// Rate, if set, limits GenerateN and Generator.Wait to Rate ruts per
+	// second on average, in bursts of up to Burst ruts, for load testing
+	Rate float64
+
+	// Burst defaults to 1, the ruts are evenly spaced
+	Burst int
+
+	// Jitter randomizes the waits of Rate by up to Jitter times the mean
+	// interval either way, eg. 0.5 for ±50%, mimicking real traffic
+	Jitter float64
+}
+
+// Generator generates random valid ruts, it isn't safe for concurrent use
+type Generator struct {
+	min, span int
+	intn      func(int) int
+	seen      *bitmap.Bitmap
+
+	// token bucket of Wait
+	rate, jitter float64
+	burst        int
+	tokens       float64
+	last         time.Time
+	float        func() float64
+}
+
+// NewGenerator returns a Generator, the Each and Progress options are ignored
+func NewGenerator(opts GenerateOptions) (*Generator, error) {
+	min, max := opts.Min, opts.Max
+	if min == 0 && max == 0 {
+		min, max = DefaultPersonRange[0], DefaultPersonRange[1]
+		if opts.Kind == KindCompany {
+			min, max = DefaultCompanyRange[0], DefaultCompanyRange[1]
+		}
+	}
+
+	switch opts.Kind {
+	case KindPerson:
+		if max > CompanyThreshold {
+			max = CompanyThreshold
+		}
+	case KindCompany:
+		if min < CompanyThreshold {
+			min = CompanyThreshold
+		}
+	}
+	// with min >= 0 the span max - min never overflows
+	if min < 0 || max <= min {
+		return nil, ErrInvalidRange
+	}
+	if opts.Rate < 0 || opts.Burst < 0 || opts.Jitter < 0 {
+		return nil, ErrInvalidRate
+	}
+
+	g := &Generator{min: min, span: max - min, intn: rand.Intn, float: rand.Float64}
+	if opts.Rand != nil {
+		g.intn, g.float = opts.Rand.Intn, opts.Rand.Float64
+	}
+	g.rate, g.burst, g.jitter = opts.Rate, opts.Burst, opts.Jitter
+	if g.burst == 0 {
+		g.burst = 1
+	}
+	if opts.Unique {
+		g.seen = new(bitmap.Bitmap)
+	}
+	return g, nil
+}
+
+// Next returns a random valid rut, with Unique it fails with
+// ErrExhausted once every rut in range was generated
+func (g *Generator) Next() (Rut, error) {
+	body, err := g.nextBody()
+	if err != nil {
+		return "", err
+	}
+	return fromBody(body), nil
+}
+
+// Wait blocks until the Rate of the options allows one more rut, it returns
+// at once without a Rate and with ctx.Err() once ctx is cancelled
+func (g *Generator) Wait(ctx context.Context) error {
+	if g.rate == 0 {
+		return ctx.Err()
+	}
+
+	// the tokens go negative while waiting, the debt delays the next calls
+	now := time.Now()
+	if g.last.IsZero() {
+		g.tokens = float64(g.burst)
+	} else {
+		g.tokens = min(float64(g.burst), g.tokens+now.Sub(g.last).Seconds()*g.rate)
+	}
+	g.last = now
+	g.tokens--
+
+	var delay float64
+	if g.tokens < 0 {
+		delay = -g.tokens / g.rate
+	}
+	if g.jitter > 0 {
+		delay = max(0, delay+(g.float()*2-1)*g.jitter/g.rate)
+	}
+	if delay == 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(time.Duration(delay * float64(time.Second)))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AppendNext appends a random valid rut rendered in the opts style to dst,
+// reusing dst between calls bulk generation doesn't allocate
+func (g *Generator) AppendNext(dst []byte, opts FormatOptions) ([]byte, error) {
+	body, err := g.nextBody()
+	if err != nil {
+		return dst, err
+	}
+	return appendStyle(dst, uint64(body), byte(computeDV(body)), opts)
+}
+
+// nextBody returns a random 'cuerpo' in range
+func (g *Generator) nextBody() (int, error) {
+	body := g.intn(g.span) + g.min
+	if g.seen != nil {
+		if g.seen.Len() == g.span {
+			return 0, ErrExhausted
+		}
+		for !g.seen.Set(uint32(body)) {
+			body = g.intn(g.span) + g.min
+		}
+	}
+	return body, nil
+}
+
+// AppendGenerate appends a random valid rut in its normalized form to dst,
+// the Unique, Each and Progress options are ignored. bulk generation
+// should reuse a Generator and its AppendNext instead
+func AppendGenerate(dst []byte, opts GenerateOptions) ([]byte, error) {
+	opts.Unique = false
+	g, err := NewGenerator(opts)
+	if err != nil {
+		return dst, err
+	}
+	return g.AppendNext(dst, FormatOptions{})
+}
+
+// GenerateN streams n valid ruts to opts.Each
+// it stops early when ctx is cancelled, returning ctx.Err()
+func GenerateN(ctx context.Context, n int, opts GenerateOptions) (err error) {
+	if opts.Each == nil {
+		return ErrNoCallback
+	}
+	g, err := NewGenerator(opts)
+	if err != nil {
+		return
+	}
+
+	every := opts.ProgressEvery
+	if every <= 0 {
+		every = DefaultProgressEvery
+	}
+
+	done := ctx.Done()
+
+	var i int
+	defer func() {
+		if opts.Progress != nil {
+			opts.Progress(i, n)
+		}
+	}()
+
+	for i < n {
+		if g.rate > 0 {
+			if err = g.Wait(ctx); err != nil {
+				return
+			}
+		} else {
+			select {
+			case <-done:
+				return ctx.Err()
+			default:
+			}
+		}
+
+		var r Rut
+		if r, err = g.Next(); err != nil {
+			return
+		}
+		if err = opts.Each(r); err != nil {
+			return
+		}
+		i++
+
+		if opts.Progress != nil && i%every == 0 && i < n {
+			opts.Progress(i, n)
+		}
+	}
+
+	return
+}
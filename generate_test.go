@@ -0,0 +1,180 @@
+package rut
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestGenerateN(t *testing.T) {
+	var generated, reports int
+	err := GenerateN(context.Background(), 25, GenerateOptions{
+		Min: 5000000,
+		Max: 23000000,
+		Each: func(r Rut) error {
+			generated++
+			if _, err := r.Validate(); err != nil {
+				t.Error(err, r)
+			}
+			return nil
+		},
+		Progress: func(done, total int) {
+			reports++
+		},
+		ProgressEvery: 10,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if generated != 25 {
+		t.Error("expected 25 ruts, got", generated)
+	}
+	// 10, 20 and the final one
+	if reports != 3 {
+		t.Error("expected 3 progress reports, got", reports)
+	}
+}
+
+func TestGenerateNSeeded(t *testing.T) {
+	collect := func() (ruts []Rut) {
+		GenerateN(context.Background(), 5, GenerateOptions{
+			Min:  5000000,
+			Max:  23000000,
+			Rand: rand.New(rand.NewSource(1)),
+			Each: func(r Rut) error {
+				ruts = append(ruts, r)
+				return nil
+			},
+		})
+		return
+	}
+
+	a, b := collect(), collect()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Error("expected same output for same seed", a[i], b[i])
+		}
+	}
+}
+
+func TestGenerateNCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var generated int
+	err := GenerateN(ctx, 1000, GenerateOptions{
+		Min: 5000000,
+		Max: 23000000,
+		Each: func(r Rut) error {
+			if generated++; generated == 10 {
+				cancel()
+			}
+			return nil
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Error("expected context.Canceled, got", err)
+	}
+	if generated != 10 {
+		t.Error("expected 10 ruts, got", generated)
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	g, err := NewGenerator(GenerateOptions{Kind: KindCompany})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		r, _ := g.Next()
+		if k, err := r.Kind(); err != nil || k != KindCompany {
+			t.Fatal(r, "expected a company, got", k, err)
+		}
+	}
+
+	if _, err := NewGenerator(GenerateOptions{Min: 60000000, Max: 70000000, Kind: KindPerson}); err != ErrInvalidRange {
+		t.Error("expected", ErrInvalidRange, "got", err)
+	}
+}
+
+func TestGeneratorUnique(t *testing.T) {
+	g, err := NewGenerator(GenerateOptions{Min: 10000000, Max: 10000100, Unique: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[Rut]bool)
+	for i := 0; i < 100; i++ {
+		r, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[r] {
+			t.Fatal("repeated", r)
+		}
+		seen[r] = true
+	}
+	if _, err := g.Next(); err != ErrExhausted {
+		t.Error("expected", ErrExhausted, "got", err)
+	}
+}
+
+func TestGeneratorAppendNext(t *testing.T) {
+	a, _ := NewGenerator(GenerateOptions{Rand: rand.New(rand.NewSource(3))})
+	b, _ := NewGenerator(GenerateOptions{Rand: rand.New(rand.NewSource(3))})
+
+	var buf []byte
+	for i := 0; i < 100; i++ {
+		r, _ := a.Next()
+		expected, _ := r.Format(FormatOptions{Style: StyleDotted})
+
+		var err error
+		if buf, err = b.AppendNext(buf[:0], FormatOptions{Style: StyleDotted}); err != nil || string(buf) != expected {
+			t.Fatal("expected", expected, "got", string(buf), err)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { buf, _ = b.AppendNext(buf[:0], FormatOptions{}) }); allocs != 0 {
+		t.Error("expected no allocations, got", allocs)
+	}
+
+	buf, err := AppendGenerate([]byte("rut: "), GenerateOptions{Kind: KindCompany})
+	if r := Rut(buf[5:]); err != nil || !bytes.HasPrefix(buf, []byte("rut: ")) || r.Key() != string(r) {
+		t.Error("unexpected rut", string(buf), err)
+	}
+	if _, err := AppendGenerate(nil, GenerateOptions{Min: 2, Max: 1}); err != ErrInvalidRange {
+		t.Error("expected", ErrInvalidRange, "got", err)
+	}
+	// max - min overflows int
+	if _, err := NewGenerator(GenerateOptions{Min: math.MinInt, Max: math.MaxInt}); err != ErrInvalidRange {
+		t.Error("expected", ErrInvalidRange, "got", err)
+	}
+}
+
+func TestGenerateNRate(t *testing.T) {
+	var n int
+	start := time.Now()
+	err := GenerateN(context.Background(), 21, GenerateOptions{Rate: 200, Each: func(Rut) error { n++; return nil }})
+	if elapsed := time.Since(start); err != nil || n != 21 || elapsed < 90*time.Millisecond || elapsed > time.Second {
+		t.Error("expected 21 ruts in about 100ms, got", n, elapsed, err)
+	}
+
+	start = time.Now()
+	opts := GenerateOptions{Rate: 1000, Burst: 10, Jitter: 0.5, Rand: rand.New(rand.NewSource(1)), Each: func(Rut) error { return nil }}
+	if err := GenerateN(context.Background(), 10, opts); err != nil || time.Since(start) > 50*time.Millisecond {
+		t.Error("expected a burst of 10, got", time.Since(start), err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := GenerateN(ctx, 3, GenerateOptions{Rate: 1, Each: func(Rut) error { return nil }}); err != context.DeadlineExceeded {
+		t.Error("expected", context.DeadlineExceeded, "got", err)
+	}
+
+	if _, err := NewGenerator(GenerateOptions{Rate: -1}); err != ErrInvalidRate {
+		t.Error("expected", ErrInvalidRate, "got", err)
+	}
+}
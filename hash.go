@@ -0,0 +1,35 @@
+package rut
+
+// FNV-1a 64 bit parameters
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash64 returns a stable hash of the rut for sharding, 0 when it's invalid.
+// it's the 64 bit FNV-1a hash of the bytes of the normalized form, every
+// spelling of a rut has the same hash and any language can compute it:
+//
+//	h := 14695981039346656037
+//	for each byte c of '12345678-5':
+//		h = (h ^ c) * 1099511628211 mod 2^64
+//
+// '12345678-5' hashes to 0x630d6f17ca969e0f. the hash never changes
+// between releases
+func (r *Rut) Hash64() uint64 {
+	key := r.Key()
+	if key == "" {
+		return 0
+	}
+	return fnv64(key)
+}
+
+// fnv64 returns the 64 bit FNV-1a hash of s
+func fnv64(s string) uint64 {
+	var h uint64 = fnvOffset64
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
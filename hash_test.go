@@ -0,0 +1,30 @@
+package rut
+
+import (
+	"hash/fnv"
+	"testing"
+)
+
+func TestHash64(t *testing.T) {
+	for _, in := range []Rut{"12345678-5", "13117182-K", "5126663-3"} {
+		h := fnv.New64a()
+		h.Write([]byte(in))
+		if got := in.Hash64(); got != h.Sum64() {
+			t.Errorf("%s: expected %#x, got %#x", in, h.Sum64(), got)
+		}
+	}
+
+	for _, in := range []Rut{"12345678-5", "12.345.678-5"} {
+		if got := in.Hash64(); got != 0x630d6f17ca969e0f {
+			t.Errorf("%s: expected the documented hash, got %#x", in, got)
+		}
+	}
+	if padded, r := Rut("01.234.567-4"), Rut("1234567-4"); padded.Hash64() != r.Hash64() {
+		t.Error("expected the zero padding to hash the same")
+	}
+
+	var r *Rut
+	if invalid := Rut("12345678-0"); r.Hash64() != 0 || invalid.Hash64() != 0 {
+		t.Error("expected 0 for invalid ruts")
+	}
+}
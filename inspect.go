@@ -0,0 +1,92 @@
+package rut
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Repair is a normalization applied to an input while validating it
+type Repair string
+
+const (
+	RepairDots       Repair = "dots_removed"
+	RepairUppercaseK Repair = "k_uppercased"
+	RepairWhitespace Repair = "whitespace_trimmed"
+)
+
+// ParseResult describes an input, see Inspect
+type ParseResult struct {
+	Input string
+
+	// Rut is the normalized form, empty when the input isn't valid
+	Rut Rut
+
+	// Body and ExpectedDV are the 'cuerpo' and the 'digito verificador'
+	// it requires, set whether DV matches or not, zero when the input is
+	// too malformed to compute them
+	Body       int
+	ExpectedDV rune
+
+	// DV is the 'digito verificador' found, uppercased
+	DV rune
+
+	// Kind classifies Body, KindAny when Body isn't set
+	Kind Kind
+
+	// Repairs are the normalizations applied to the input, in order
+	Repairs []Repair
+
+	// Leading and Trailing are the whitespace InspectTrimmed stripped
+	// around the input
+	Leading, Trailing string
+}
+
+// Valid reports whether the input is a valid rut
+func (res ParseResult) Valid() bool {
+	return res.Rut != ""
+}
+
+// Inspect validates s and describes it in a single pass, err is the
+// validation error
+func Inspect(s string) (res ParseResult, err error) {
+	res.Input = s
+
+	undotted := strings.Replace(s, ".", "", -1)
+	if undotted != s {
+		res.Repairs = append(res.Repairs, RepairDots)
+	}
+	if strings.HasSuffix(s, "k") {
+		res.Repairs = append(res.Repairs, RepairUppercaseK)
+	}
+
+	r := Rut(s)
+	info, err := r.Validate()
+	if info == nil {
+		return
+	}
+
+	res.Body, res.ExpectedDV = info.Body, info.ExpectedDV
+	res.DV = rune(r[len(r)-1])
+	res.Kind = kindOf(res.Body)
+	if err == nil {
+		res.Rut = r
+	}
+	return
+}
+
+// InspectTrimmed is Inspect ignoring the whitespace around s, eg. the
+// ' 12.345.678-5\n' of a copy-paste. the whitespace stripped is reported
+// in Leading and Trailing with RepairWhitespace leading the Repairs, so
+// the callers accepting it can tell their clients how to send it next time
+func InspectTrimmed(s string) (res ParseResult, err error) {
+	left := strings.TrimLeftFunc(s, unicode.IsSpace)
+	trimmed := strings.TrimRightFunc(left, unicode.IsSpace)
+
+	res, err = Inspect(trimmed)
+	res.Input = s
+	if trimmed != s {
+		res.Leading, res.Trailing = s[:len(s)-len(left)], left[len(trimmed):]
+		res.Repairs = append([]Repair{RepairWhitespace}, res.Repairs...)
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package rut
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	res, err := Inspect("76.086.428-k")
+	if err != ErrinvalidDV {
+		t.Fatal("expected ErrinvalidDV, got", err)
+	}
+	expected := ParseResult{
+		Input:      "76.086.428-k",
+		Body:       76086428,
+		ExpectedDV: '5',
+		DV:         'K',
+		Kind:       KindCompany,
+		Repairs:    []Repair{RepairDots, RepairUppercaseK},
+	}
+	if !reflect.DeepEqual(res, expected) || res.Valid() {
+		t.Errorf("expected %+v, got %+v", expected, res)
+	}
+
+	res, err = Inspect("13117182-k")
+	if err != nil || !res.Valid() || res.Rut != "13117182-K" || res.Body != 13117182 || res.DV != 'K' || res.Kind != KindPerson {
+		t.Errorf("unexpected result %+v %v", res, err)
+	}
+	if !reflect.DeepEqual(res.Repairs, []Repair{RepairUppercaseK}) {
+		t.Error("unexpected repairs", res.Repairs)
+	}
+
+	if res, err := Inspect("12345678-5"); err != nil || res.Repairs != nil {
+		t.Errorf("unexpected result %+v %v", res, err)
+	}
+
+	res, err = Inspect("12.3a5.678-5")
+	if err != ErrExpectedDigit || res.Body != 0 || res.ExpectedDV != 0 || res.Kind != KindAny {
+		t.Errorf("unexpected result %+v %v", res, err)
+	}
+}
+
+func TestInspectTrimmed(t *testing.T) {
+	res, err := InspectTrimmed(" 12.345.678-5\r\n")
+	if err != nil || res.Rut != "12345678-5" || res.Input != " 12.345.678-5\r\n" || res.Leading != " " || res.Trailing != "\r\n" {
+		t.Errorf("unexpected result %+v %v", res, err)
+	}
+	if !reflect.DeepEqual(res.Repairs, []Repair{RepairWhitespace, RepairDots}) {
+		t.Error("unexpected repairs", res.Repairs)
+	}
+
+	if res, err := InspectTrimmed("12345678-5"); err != nil || res.Repairs != nil || res.Leading != "" || res.Trailing != "" {
+		t.Errorf("unexpected result %+v %v", res, err)
+	}
+	if _, err := InspectTrimmed(" \t "); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+}
@@ -0,0 +1,206 @@
+// Package bitmap implements a set of uint32 backed by lazily allocated
+// pages, dense ranges like rut 'cuerpos' cost one bit per value
+package bitmap
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+var ErrCorrupt = errors.New("corrupt bitmap encoding")
+
+const (
+	pagebits  = 16
+	pagewords = 1 << pagebits / 64
+)
+
+type page [pagewords]uint64
+
+// Bitmap is a set of uint32, the zero value is an empty set
+type Bitmap struct {
+	pages []*page
+	n     int
+}
+
+// Set adds x, it reports whether x wasn't present
+func (b *Bitmap) Set(x uint32) bool {
+	hi := int(x >> pagebits)
+	if hi >= len(b.pages) {
+		b.pages = append(b.pages, make([]*page, hi+1-len(b.pages))...)
+	}
+	p := b.pages[hi]
+	if p == nil {
+		p = new(page)
+		b.pages[hi] = p
+	}
+
+	w, mask := (x&(1<<pagebits-1))/64, uint64(1)<<(x%64)
+	if p[w]&mask != 0 {
+		return false
+	}
+	p[w] |= mask
+	b.n++
+	return true
+}
+
+// Has reports whether x is present
+func (b *Bitmap) Has(x uint32) bool {
+	hi := int(x >> pagebits)
+	if hi >= len(b.pages) || b.pages[hi] == nil {
+		return false
+	}
+	return b.pages[hi][(x&(1<<pagebits-1))/64]&(uint64(1)<<(x%64)) != 0
+}
+
+// Len returns the number of values
+func (b *Bitmap) Len() int {
+	return b.n
+}
+
+// Iterate calls fn with every value in ascending order until fn returns false
+func (b *Bitmap) Iterate(fn func(uint32) bool) {
+	for hi, p := range b.pages {
+		if p == nil {
+			continue
+		}
+		for w, word := range p {
+			for word != 0 {
+				bit := bits.TrailingZeros64(word)
+				if !fn(uint32(hi)<<pagebits | uint32(w*64+bit)) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Union returns the values in a or b
+func Union(a, b *Bitmap) *Bitmap {
+	return combine(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersect returns the values in both a and b
+func Intersect(a, b *Bitmap) *Bitmap {
+	return combine(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns the values in a but not in b
+func Difference(a, b *Bitmap) *Bitmap {
+	return combine(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// combine applies op word by word, missing pages are empty
+// and empty results aren't allocated
+func combine(a, b *Bitmap, op func(x, y uint64) uint64) *Bitmap {
+	var empty page
+
+	n := len(a.pages)
+	if len(b.pages) > n {
+		n = len(b.pages)
+	}
+
+	c := &Bitmap{pages: make([]*page, n)}
+	for hi := range c.pages {
+		pa, pb := &empty, &empty
+		if hi < len(a.pages) && a.pages[hi] != nil {
+			pa = a.pages[hi]
+		}
+		if hi < len(b.pages) && b.pages[hi] != nil {
+			pb = b.pages[hi]
+		}
+		if pa == &empty && pb == &empty {
+			continue
+		}
+
+		p := new(page)
+		var count int
+		for w := range p {
+			p[w] = op(pa[w], pb[w])
+			count += bits.OnesCount64(p[w])
+		}
+		if count > 0 {
+			c.pages[hi] = p
+			c.n += count
+		}
+	}
+	return c
+}
+
+// WriteTo encodes the bitmap as the number of allocated pages followed by
+// each page index and words, all little endian
+func (b *Bitmap) WriteTo(w io.Writer) (n int64, err error) {
+	var count uint32
+	for _, p := range b.pages {
+		if p != nil {
+			count++
+		}
+	}
+
+	buf := make([]byte, 4+pagewords*8)
+	binary.LittleEndian.PutUint32(buf, count)
+	m, err := w.Write(buf[:4])
+	if n += int64(m); err != nil {
+		return
+	}
+
+	for hi, p := range b.pages {
+		if p == nil {
+			continue
+		}
+		binary.LittleEndian.PutUint32(buf, uint32(hi))
+		for i, word := range p {
+			binary.LittleEndian.PutUint64(buf[4+i*8:], word)
+		}
+		m, err = w.Write(buf)
+		if n += int64(m); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ReadFrom replaces the bitmap with one encoded by WriteTo,
+// it reads exactly the encoded bytes
+func (b *Bitmap) ReadFrom(r io.Reader) (n int64, err error) {
+	buf := make([]byte, 4+pagewords*8)
+	m, err := io.ReadFull(r, buf[:4])
+	if n += int64(m); err != nil {
+		return
+	}
+	count := binary.LittleEndian.Uint32(buf)
+	if count > 1<<(32-pagebits) {
+		return n, ErrCorrupt
+	}
+
+	var c Bitmap
+	last := -1
+	for ; count > 0; count-- {
+		m, err = io.ReadFull(r, buf)
+		if n += int64(m); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+
+		hi := int(binary.LittleEndian.Uint32(buf))
+		if hi <= last || hi >= 1<<(32-pagebits) {
+			return n, ErrCorrupt
+		}
+		last = hi
+
+		p := new(page)
+		for i := range p {
+			p[i] = binary.LittleEndian.Uint64(buf[4+i*8:])
+			c.n += bits.OnesCount64(p[i])
+		}
+		c.pages = append(c.pages, make([]*page, hi+1-len(c.pages))...)
+		c.pages[hi] = p
+	}
+
+	*b = c
+	return
+}
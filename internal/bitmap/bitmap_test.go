@@ -0,0 +1,71 @@
+package bitmap
+
+import (
+	"testing"
+)
+
+func TestBitmap(t *testing.T) {
+	var b Bitmap
+
+	values := []uint32{0, 1, 63, 64, 65535, 65536, 99999999, 1<<32 - 1}
+	for i := len(values) - 1; i >= 0; i-- {
+		if !b.Set(values[i]) {
+			t.Error(values[i], "reported as present")
+		}
+	}
+	if b.Set(64) {
+		t.Error("64 reported as added twice")
+	}
+	if b.Len() != len(values) {
+		t.Error("expected", len(values), "got", b.Len())
+	}
+	if b.Has(2) || !b.Has(99999999) {
+		t.Error("unexpected Has")
+	}
+
+	var i int
+	b.Iterate(func(x uint32) bool {
+		if x != values[i] {
+			t.Error("expected", values[i], "got", x)
+		}
+		i++
+		return true
+	})
+	if i != len(values) {
+		t.Error("expected", len(values), "iterated values, got", i)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	var a, b Bitmap
+	for _, x := range []uint32{1, 2, 70000, 1 << 20} {
+		a.Set(x)
+	}
+	for _, x := range []uint32{2, 3, 1 << 20, 1 << 30} {
+		b.Set(x)
+	}
+
+	for name, tc := range map[string]struct {
+		got      *Bitmap
+		expected []uint32
+	}{
+		"union":      {Union(&a, &b), []uint32{1, 2, 3, 70000, 1 << 20, 1 << 30}},
+		"intersect":  {Intersect(&a, &b), []uint32{2, 1 << 20}},
+		"difference": {Difference(&a, &b), []uint32{1, 70000}},
+	} {
+		var got []uint32
+		tc.got.Iterate(func(x uint32) bool {
+			got = append(got, x)
+			return true
+		})
+		if len(got) != len(tc.expected) || tc.got.Len() != len(tc.expected) {
+			t.Fatal(name, "expected", tc.expected, "got", got, tc.got.Len())
+		}
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Error(name, "expected", tc.expected, "got", got)
+				break
+			}
+		}
+	}
+}
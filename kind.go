@@ -0,0 +1,56 @@
+package rut
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrUnknownKind = errors.New("unknown kind, expected any, person or company")
+
+// CompanyThreshold is the first 'cuerpo' SII assigns to companies
+// (personas jurídicas), natural persons are below it
+const CompanyThreshold = 50000000
+
+// Kind classifies ruts by their 'cuerpo'
+type Kind int
+
+const (
+	KindAny Kind = iota
+	KindPerson
+	KindCompany
+)
+
+var kindnames = [...]string{KindAny: "any", KindPerson: "person", KindCompany: "company"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindnames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindnames[k]
+}
+
+// ParseKind parses the String form of a Kind
+func ParseKind(s string) (Kind, error) {
+	for k, name := range kindnames {
+		if s == name {
+			return Kind(k), nil
+		}
+	}
+	return KindAny, ErrUnknownKind
+}
+
+// Kind validates the rut and classifies it as KindPerson or KindCompany
+func (r *Rut) Kind() (Kind, error) {
+	body, err := r.Body()
+	if err != nil {
+		return KindAny, err
+	}
+	return kindOf(body), nil
+}
+
+func kindOf(body int) Kind {
+	if body >= CompanyThreshold {
+		return KindCompany
+	}
+	return KindPerson
+}
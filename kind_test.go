@@ -0,0 +1,30 @@
+package rut
+
+import (
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	for in, expected := range map[Rut]Kind{
+		"12.345.678-5": KindPerson,
+		"76.086.428-5": KindCompany,
+	} {
+		if got, err := in.Kind(); err != nil || got != expected {
+			t.Error(in, "expected", expected, "got", got, err)
+		}
+	}
+
+	invalid := Rut("12345678-0")
+	if _, err := invalid.Kind(); err != ErrinvalidDV {
+		t.Error("expected", ErrinvalidDV, "got", err)
+	}
+
+	for _, k := range []Kind{KindAny, KindPerson, KindCompany} {
+		if parsed, err := ParseKind(k.String()); err != nil || parsed != k {
+			t.Error(k, "round trip failed", parsed, err)
+		}
+	}
+	if _, err := ParseKind("nope"); err != ErrUnknownKind {
+		t.Error("expected", ErrUnknownKind, "got", err)
+	}
+}
@@ -0,0 +1,39 @@
+package rut
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+const bom = "\ufeff"
+
+// ValidateLines validates one rut per line of r and calls fn with the
+// line number, from 1, the line without its "\n" or "\r\n" ending and the
+// Result. a UTF-8 BOM starting r is dropped and blank lines, empty or
+// whitespace only, are skipped but counted. lines have no length limit, a
+// huge one is one more invalid rut. it returns the read error
+func ValidateLines(r io.Reader, fn func(line int, raw string, res Result)) error {
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == io.EOF && line == "" {
+			return nil
+		}
+
+		raw := strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if n == 1 {
+			raw = strings.TrimPrefix(raw, bom)
+		}
+		if strings.TrimSpace(raw) != "" {
+			fn(n, raw, Check(raw))
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
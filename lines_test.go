@@ -0,0 +1,51 @@
+package rut
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLines(t *testing.T) {
+	in := "\ufeff12.345.678-5\r\n\r\n  \n12345678-0\n13117182-k"
+
+	type call struct {
+		line int
+		raw  string
+		err  error
+	}
+	var calls []call
+	err := ValidateLines(strings.NewReader(in), func(line int, raw string, res Result) {
+		if res.Input != raw {
+			t.Error("unexpected input", res.Input)
+		}
+		calls = append(calls, call{line, raw, res.Err})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []call{{1, "12.345.678-5", nil}, {4, "12345678-0", ErrinvalidDV}, {5, "13117182-k", nil}}
+	if len(calls) != len(expected) {
+		t.Fatal("expected", expected, "got", calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Error("expected", expected[i], "got", calls[i])
+		}
+	}
+}
+
+func TestValidateLinesLongLine(t *testing.T) {
+	in := "12345678-5\n" + strings.Repeat("1", 100000) + "\n13117182-k\n"
+
+	var errs []error
+	err := ValidateLines(strings.NewReader(in), func(line int, raw string, res Result) {
+		errs = append(errs, res.Err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 3 || errs[0] != nil || errs[1] != ErrMaxLength || errs[2] != nil {
+		t.Error("unexpected results", errs)
+	}
+}
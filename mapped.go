@@ -0,0 +1,75 @@
+package rut
+
+import (
+	"bytes"
+	"context"
+	"unsafe"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+// mappedCheckEvery is the number of lines ValidateMapped validates
+// between checks of its context
+const mappedCheckEvery = 1 << 16
+
+// ValidateMapped validates one rut per line of the file at path, the lines
+// are read as ValidateLines does. the file is memory mapped where the
+// platform supports it and validated in place, only the invalid lines are
+// copied, so files larger than RAM take little more memory than the
+// report. fn, if set, is
+// called with the byte offset of the line in the file, the line number
+// and the Result of every invalid line, returning an error stops the
+// validation and ValidateMapped returns it. once ctx is cancelled it stops
+// and returns ctx.Err()
+func ValidateMapped(ctx context.Context, path string, fn func(offset int64, line int, res Result) error) (report Report, err error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return
+	}
+	defer unmap()
+
+	var offset int
+	for n := 1; offset < len(data); n++ {
+		if n%mappedCheckEvery == 0 {
+			if err = ctx.Err(); err != nil {
+				return
+			}
+		}
+
+		raw := data[offset:]
+		next := len(data)
+		if i := bytes.IndexByte(raw, '\n'); i >= 0 {
+			raw, next = raw[:i], offset+i+1
+		}
+		start := offset
+		offset = next
+
+		raw = bytes.TrimSuffix(raw, []byte("\r"))
+		if n == 1 {
+			raw = bytes.TrimPrefix(raw, []byte(bom))
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		// the line is only read while validating, it's never retained
+		body, _, st := rutcore.Check(unsafe.String(unsafe.SliceData(raw), len(raw)), MinRutlength, MaxRutlength)
+		if st == rutcore.OK {
+			report.Total++
+			report.addValid(body)
+			continue
+		}
+
+		res := Result{Input: string(raw), Err: statusError(st)}
+		if st == rutcore.ErrInvalidDV {
+			res.ExpectedDV = rune(rutcore.DV(body))
+		}
+		report.Add(res)
+		if fn != nil {
+			if err = fn(int64(start), n, res); err != nil {
+				return
+			}
+		}
+	}
+	return report, ctx.Err()
+}
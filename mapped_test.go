@@ -0,0 +1,78 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateMapped(t *testing.T) {
+	in := "\ufeff12.345.678-5\r\n\r\n  \n12345678-0\n1-9\r\n12345678-5\n13117182-k"
+	path := filepath.Join(t.TempDir(), "ruts.txt")
+	if err := os.WriteFile(path, []byte(in), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		offset int64
+		line   int
+		res    Result
+	}
+	var calls []call
+	report, err := ValidateMapped(context.Background(), path, func(offset int64, line int, res Result) error {
+		calls = append(calls, call{offset, line, res})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []call{
+		{int64(strings.Index(in, "12345678-0")), 4, Result{Input: "12345678-0", ExpectedDV: '5', Err: ErrinvalidDV}},
+		{int64(strings.Index(in, "1-9")), 5, Result{Input: "1-9", Err: ErrMinLength}},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %+v, got %+v", expected, calls)
+	}
+
+	var lines Report
+	ValidateLines(strings.NewReader(in), func(_ int, _ string, res Result) { lines.Add(res) })
+	if report.Total != 5 || report.Valid != lines.Valid || report.Duplicates != 1 || !reflect.DeepEqual(report.Errors, lines.Errors) {
+		t.Errorf("expected %+v, got %+v", lines, report)
+	}
+}
+
+func TestValidateMappedStop(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := ValidateMapped(context.Background(), empty, nil); err != nil || report.Total != 0 {
+		t.Error("unexpected result", report, err)
+	}
+
+	if _, err := ValidateMapped(context.Background(), filepath.Join(dir, "missing.txt"), nil); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected os.ErrNotExist, got", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.txt")
+	if err := os.WriteFile(invalid, []byte("1-9\n12345678-0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+	report, err := ValidateMapped(context.Background(), invalid, func(int64, int, Result) error { return stop })
+	if err != stop || report.Total != 1 {
+		t.Error("expected to stop at the first failure, got", report.Total, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ValidateMapped(ctx, invalid, nil); err != context.Canceled {
+		t.Error("expected context.Canceled, got", err)
+	}
+}
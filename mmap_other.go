@@ -0,0 +1,13 @@
+//go:build !unix
+
+package rut
+
+import "os"
+
+// mapFile reads the file at path whole where memory mapping isn't supported
+func mapFile(path string) (data []byte, unmap func() error, err error) {
+	if data, err = os.ReadFile(path); err != nil {
+		return
+	}
+	return data, func() error { return nil }, nil
+}
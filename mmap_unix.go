@@ -0,0 +1,30 @@
+//go:build unix
+
+package rut
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path read only, unmap releases it
+func mapFile(path string) (data []byte, unmap func() error, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return
+	}
+	// mmap rejects empty files
+	if fi.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	if data, err = syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED); err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,230 @@
+package rut
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of the FileOptions fields left unset
+const (
+	DefaultBatchLines    = 4096
+	DefaultProgressDelay = time.Second
+)
+
+// FileOptions configures ValidateFile and ValidateReader
+type FileOptions struct {
+	// Workers validating the batches, defaults to runtime.GOMAXPROCS(0)
+	Workers int
+
+	// BatchLines is the number of lines handed to a worker at once,
+	// defaults to DefaultBatchLines
+	BatchLines int
+
+	// Progress, if set, is called at most every ProgressDelay and once
+	// more when the validation stops
+	Progress func(Progress)
+
+	// ProgressDelay defaults to DefaultProgressDelay
+	ProgressDelay time.Duration
+}
+
+// Progress reports the advance of ValidateFile
+type Progress struct {
+	// Lines processed, blank ones included
+	Lines int
+
+	// Bytes processed out of TotalBytes, TotalBytes is 0 when unknown
+	Bytes, TotalBytes int64
+
+	Elapsed time.Duration
+
+	// Rate is the number of lines processed per second
+	Rate float64
+
+	// ETA estimates the time left from Bytes and TotalBytes, 0 when unknown
+	ETA time.Duration
+}
+
+// ValidateFile is ValidateReader over the file at path, its size is the
+// Progress.TotalBytes
+func ValidateFile(ctx context.Context, path string, opts FileOptions, fn func(line int, raw string, res Result) error) (report Report, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var size int64
+	if fi, serr := f.Stat(); serr == nil && fi.Mode().IsRegular() {
+		size = fi.Size()
+	}
+	return ValidateReader(ctx, f, size, opts, fn)
+}
+
+// fileBatch is a run of consecutive lines validated by a single worker
+type fileBatch struct {
+	seq, first int
+	lines      []string
+	bytes      int64
+	results    []Result
+}
+
+// ValidateReader validates one rut per line of r across workers, the lines
+// are read as ValidateLines does. fn is called from a single goroutine, in
+// line order, returning an error stops the validation and ValidateReader
+// returns it. size is the expected length of r for the Progress ETA, 0 when
+// unknown. once ctx is cancelled it stops and returns ctx.Err(), the
+// returned report accounts the results passed to fn
+func ValidateReader(ctx context.Context, r io.Reader, size int64, opts FileOptions, fn func(line int, raw string, res Result) error) (report Report, err error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	batchLines := opts.BatchLines
+	if batchLines <= 0 {
+		batchLines = DefaultBatchLines
+	}
+	delay := opts.ProgressDelay
+	if delay <= 0 {
+		delay = DefaultProgressDelay
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		jobs    = make(chan *fileBatch, workers)
+		done    = make(chan *fileBatch, workers)
+		readErr = make(chan error, 1)
+		wg      sync.WaitGroup
+	)
+
+	// reader
+	go func() {
+		defer close(jobs)
+		var read, sent int64
+		br := bufio.NewReader(r)
+		b := &fileBatch{first: 1}
+		for line := 1; ; line++ {
+			s, rerr := br.ReadString('\n')
+			more := rerr == nil
+			if s != "" && (more || rerr == io.EOF) {
+				read += int64(len(s))
+				b.lines = append(b.lines, strings.TrimSuffix(s, "\n"))
+			}
+			if len(b.lines) == batchLines || (!more && len(b.lines) > 0) {
+				b.bytes, sent = read-sent, read
+				select {
+				case jobs <- b:
+				case <-ctx.Done():
+					return
+				}
+				b = &fileBatch{seq: b.seq + 1, first: line + 1}
+			}
+			if !more {
+				if rerr == io.EOF {
+					rerr = nil
+				}
+				readErr <- rerr
+				return
+			}
+		}
+	}()
+
+	// workers
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				b.results = make([]Result, len(b.lines))
+				for i, raw := range b.lines {
+					raw = strings.TrimSuffix(raw, "\r")
+					if b.first+i == 1 {
+						raw = strings.TrimPrefix(raw, bom)
+					}
+					if strings.TrimSpace(raw) == "" {
+						raw = ""
+					} else {
+						b.results[i] = Check(raw)
+					}
+					b.lines[i] = raw
+				}
+				select {
+				case done <- b:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// collector, batches are emitted in order
+	var (
+		progress = Progress{TotalBytes: size}
+		start    = time.Now()
+		last     = start
+		pending  = make(map[int]*fileBatch)
+		next     int
+	)
+	emitProgress := func() {
+		progress.Elapsed = time.Since(start)
+		if s := progress.Elapsed.Seconds(); s > 0 {
+			progress.Rate = float64(progress.Lines) / s
+		}
+		if progress.Bytes > 0 && progress.TotalBytes > progress.Bytes {
+			progress.ETA = time.Duration(float64(progress.Elapsed) * float64(progress.TotalBytes-progress.Bytes) / float64(progress.Bytes))
+		} else {
+			progress.ETA = 0
+		}
+		opts.Progress(progress)
+	}
+
+	for b := range done {
+		if err != nil {
+			// draining until the workers stop
+			continue
+		}
+		pending[b.seq] = b
+		for b, ok := pending[next]; ok && err == nil; b, ok = pending[next] {
+			delete(pending, next)
+			next++
+			for i, raw := range b.lines {
+				if raw == "" {
+					continue
+				}
+				report.Add(b.results[i])
+				if err = fn(b.first+i, raw, b.results[i]); err != nil {
+					cancel()
+					break
+				}
+			}
+			progress.Lines += len(b.lines)
+			progress.Bytes += b.bytes
+		}
+		if opts.Progress != nil && time.Since(last) >= delay {
+			last = time.Now()
+			emitProgress()
+		}
+	}
+
+	if opts.Progress != nil {
+		emitProgress()
+	}
+	if err == nil {
+		if err = ctx.Err(); err == nil {
+			err = <-readErr
+		}
+	}
+	return
+}
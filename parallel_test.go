@@ -0,0 +1,115 @@
+package rut
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateFile(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("\ufeff")
+	const n = 10000
+	for i := 0; i < n; i++ {
+		switch {
+		case i%100 == 7:
+			sb.WriteString("\r\n")
+		case i%10 == 3:
+			sb.WriteString("12345678-0\r\n")
+		default:
+			sb.WriteString(string(fromBody(1000000+i)) + "\n")
+		}
+	}
+	path := filepath.Join(t.TempDir(), "ruts.txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		prev     int
+		last     Progress
+		progress int
+	)
+	opts := FileOptions{Workers: 4, BatchLines: 100, Progress: func(p Progress) {
+		progress++
+		last = p
+	}}
+	report, err := ValidateFile(context.Background(), path, opts, func(line int, raw string, res Result) error {
+		if line <= prev {
+			t.Fatal("line", line, "after", prev)
+		}
+		prev = line
+
+		i := line - 1
+		switch {
+		case i%100 == 7:
+			t.Fatal("blank line", line, "not skipped")
+		case i%10 == 3:
+			if res.Err != ErrinvalidDV || raw != "12345678-0" {
+				t.Fatal("unexpected result", line, res)
+			}
+		default:
+			if res.Err != nil || res.Rut != fromBody(1000000+i) {
+				t.Fatal("unexpected result", line, res)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Total != n-n/100 || report.Invalid != n/10 {
+		t.Error("unexpected report", report.Total, report.Invalid)
+	}
+	if progress == 0 || last.Lines != n || last.Bytes != int64(sb.Len()) || last.TotalBytes != int64(sb.Len()) || last.ETA != 0 {
+		t.Errorf("unexpected progress %+v", last)
+	}
+}
+
+func TestValidateReaderStop(t *testing.T) {
+	in := strings.Repeat("12345678-5\n", 10000)
+
+	stop := errors.New("stop")
+	var calls int
+	report, err := ValidateReader(context.Background(), strings.NewReader(in), 0, FileOptions{BatchLines: 10}, func(line int, raw string, res Result) error {
+		if calls++; calls == 25 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || calls != 25 || report.Total != 25 {
+		t.Error("unexpected stop", err, calls, report.Total)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	_, err = ValidateReader(ctx, strings.NewReader(in), 0, FileOptions{BatchLines: 10}, func(line int, raw string, res Result) error {
+		if calls++; calls == 25 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled || calls >= 10000 {
+		t.Error("expected context.Canceled, got", err, calls)
+	}
+}
+
+func TestValidateReaderLongLine(t *testing.T) {
+	in := "12345678-5\n" + strings.Repeat("1", 100000) + "\r\n13117182-k"
+
+	var lines []int
+	report, err := ValidateReader(context.Background(), strings.NewReader(in), 0, FileOptions{}, func(line int, raw string, res Result) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 || report.Total != 3 || report.Valid != 2 || report.Errors["max_length"] != 1 {
+		t.Error("unexpected validation", lines, report)
+	}
+}
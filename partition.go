@@ -0,0 +1,98 @@
+package rut
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Partition returns the partition of the rut among n, -1 when the rut is
+// invalid or n < 1. it's the jump consistent hash of Lamping and Veach
+// over Hash64, so growing n moves only the ruts landing in the new
+// partitions and every service computing it agrees
+func (r *Rut) Partition(n int) int {
+	key := r.Hash64()
+	if key == 0 || n < 1 {
+		return -1
+	}
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(1<<31) / float64(key>>33+1)))
+	}
+	return int(b)
+}
+
+// DefaultReplicas is the number of points of every node of a Ring when
+// NewRing is given none
+const DefaultReplicas = 100
+
+// Ring maps ruts to named nodes by consistent hashing, adding or removing
+// a node moves only the ruts of that node. a node has replicas points on
+// the ring at mix64 of the 64 bit FNV-1a hashes of 'node#0', 'node#1' and
+// so on, a rut belongs to the node of the first point at or after mix64 of
+// its Hash64, wrapping around. mix64 is the splitmix64 finalizer
+//
+//	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+//	h = (h ^ h>>27) * 0x94d049bb133111eb
+//	h = h ^ h>>31
+//
+// which spreads the similar hashes of similar names and ruts over the
+// ring. other services must apply it too to agree on the nodes.
+// it's immutable and safe for concurrent use
+type Ring struct {
+	points []ringPoint
+}
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// NewRing returns the Ring of nodes with replicas points each,
+// replicas < 1 uses DefaultReplicas
+func NewRing(replicas int, nodes ...string) *Ring {
+	if replicas < 1 {
+		replicas = DefaultReplicas
+	}
+	g := &Ring{points: make([]ringPoint, 0, len(nodes)*replicas)}
+	for _, node := range nodes {
+		for i := 0; i < replicas; i++ {
+			g.points = append(g.points, ringPoint{mix64(fnv64(node + "#" + strconv.Itoa(i))), node})
+		}
+	}
+	// ties are broken by node so the order doesn't depend on the arguments
+	slices.SortFunc(g.points, func(a, b ringPoint) int {
+		if c := cmp.Compare(a.hash, b.hash); c != 0 {
+			return c
+		}
+		return strings.Compare(a.node, b.node)
+	})
+	return g
+}
+
+// Node returns the node of the rut, "" when the rut is invalid or the
+// ring has no nodes
+func (g *Ring) Node(r Rut) string {
+	h := r.Hash64()
+	if h == 0 || len(g.points) == 0 {
+		return ""
+	}
+	i, _ := slices.BinarySearchFunc(g.points, mix64(h), func(p ringPoint, h uint64) int {
+		return cmp.Compare(p.hash, h)
+	})
+	if i == len(g.points) {
+		i = 0
+	}
+	return g.points[i].node
+}
+
+// mix64 is the finalizer of splitmix64, it spreads the bits of h,
+// see Ring
+func mix64(h uint64) uint64 {
+	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+	h = (h ^ h>>27) * 0x94d049bb133111eb
+	return h ^ h>>31
+}
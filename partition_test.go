@@ -0,0 +1,77 @@
+package rut
+
+import (
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	const n = 10
+	counts := make([]int, n)
+	for body := 10000000; body < 10010000; body++ {
+		r := fromBody(body)
+		p := r.Partition(n)
+		if p < 0 || p >= n {
+			t.Fatal(r, "partition out of range", p)
+		}
+		counts[p]++
+
+		// growing n only moves ruts to the new partition
+		if q := r.Partition(n + 1); q != p && q != n {
+			t.Fatal(r, "moved from", p, "to", q)
+		}
+	}
+	for p, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Error("unbalanced partition", p, c)
+		}
+	}
+
+	r := Rut("12.345.678-5")
+	if p := r.Partition(1); p != 0 {
+		t.Error("expected 0, got", p)
+	}
+	if p := r.Partition(0); p != -1 {
+		t.Error("expected -1, got", p)
+	}
+	if invalid := Rut("12345678-0"); invalid.Partition(n) != -1 {
+		t.Error("expected -1 for an invalid rut")
+	}
+}
+
+func TestMix64(t *testing.T) {
+	// the first output of splitmix64 seeded with 0
+	if h := mix64(0x9e3779b97f4a7c15); h != 0xe220a8397b1dcdaf {
+		t.Errorf("expected 0xe220a8397b1dcdaf, got %#x", h)
+	}
+}
+
+func TestRing(t *testing.T) {
+	a := NewRing(0, "a", "b", "c")
+	b := NewRing(DefaultReplicas, "c", "a", "b")
+	grown := NewRing(0, "a", "b", "c", "d")
+
+	counts := make(map[string]int)
+	for body := 10000000; body < 10010000; body++ {
+		r := fromBody(body)
+		node := a.Node(r)
+		if node == "" || node != b.Node(r) {
+			t.Fatal(r, "unexpected node", node, b.Node(r))
+		}
+		counts[node]++
+		if g := grown.Node(r); g != node && g != "d" {
+			t.Fatal(r, "moved from", node, "to", g)
+		}
+	}
+	for node, c := range counts {
+		if c < 2000 {
+			t.Error("unbalanced node", node, c)
+		}
+	}
+
+	if node := a.Node("12345678-0"); node != "" {
+		t.Error("expected no node for an invalid rut, got", node)
+	}
+	if node := NewRing(0).Node("12345678-5"); node != "" {
+		t.Error("expected no node for an empty ring, got", node)
+	}
+}
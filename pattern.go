@@ -0,0 +1,46 @@
+package rut
+
+import "regexp"
+
+// Regular expressions of the rut format, they don't check the
+// 'digito verificador' and assume the default MinRutlength and MaxRutlength
+const (
+	// PatternStrict matches the canonical form produced by Validate,
+	// 'NNNNNNN-N' or 'NNNNNNNN-N' with an uppercase 'K'
+	PatternStrict = `^[1-9][0-9]{6,7}-[0-9K]$`
+
+	// PatternLenient also matches the dotted form and a lowercase 'k',
+	// every match is accepted by Validate unless the 'digito verificador'
+	// is wrong
+	PatternLenient = `^(?:[1-9][0-9]{6,7}|[1-9][0-9]?\.[0-9]{3}\.[0-9]{3})-[0-9Kk]$`
+)
+
+// Compiled PatternStrict and PatternLenient
+var (
+	RegexpStrict  = regexp.MustCompile(PatternStrict)
+	RegexpLenient = regexp.MustCompile(PatternLenient)
+)
+
+// JSONSchema returns the JSON Schema of a rut string field, also valid as
+// an OpenAPI schema object, with PatternStrict or, when lenient is set,
+// PatternLenient as its pattern
+func JSONSchema(lenient bool) map[string]any {
+	if lenient {
+		return map[string]any{
+			"type":        "string",
+			"pattern":     PatternLenient,
+			"minLength":   MinRutlength,
+			"maxLength":   MaxRutlength + 2,
+			"description": "Rol Único Tributario, with or without decimal points, eg. 12.345.678-5 or 12345678-5",
+			"examples":    []string{"12.345.678-5", "12345678-5", "13117182-k"},
+		}
+	}
+	return map[string]any{
+		"type":        "string",
+		"pattern":     PatternStrict,
+		"minLength":   MinRutlength,
+		"maxLength":   MaxRutlength,
+		"description": "Rol Único Tributario in canonical form, 'cuerpo' without decimal points, '-' and uppercase 'digito verificador', eg. 12345678-5",
+		"examples":    []string{"12345678-5", "13117182-K"},
+	}
+}
@@ -0,0 +1,68 @@
+package rut
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestPatterns(t *testing.T) {
+	for input, expected := range map[string][2]bool{
+		"12345678-5":   {true, true},
+		"1234567-4":    {true, true},
+		"12.345.678-5": {false, true},
+		"1.234.567-4":  {false, true},
+		"13117182-k":   {false, true},
+		"13117182-K":   {true, true},
+		"123456-0":     {false, false},
+		"123456789-0":  {false, false},
+		"01234567-4":   {false, false},
+		"12.34.5678-5": {false, false},
+		"12345678-x":   {false, false},
+		"12345678K":    {false, false},
+	} {
+		if got := RegexpStrict.MatchString(input); got != expected[0] {
+			t.Error(input, "strict expected", expected[0], "got", got)
+		}
+		if got := RegexpLenient.MatchString(input); got != expected[1] {
+			t.Error(input, "lenient expected", expected[1], "got", got)
+		}
+	}
+}
+
+// every strict match of a valid rut is its own canonical form,
+// and every lenient match only fails Validate on the 'digito verificador'
+func TestPatternsAgreeWithValidate(t *testing.T) {
+	for body := 1000000; body < 100000000; body += 7919 {
+		r := fromBody(body)
+		if !RegexpStrict.MatchString(string(r)) {
+			t.Fatal("strict pattern rejects", r)
+		}
+		dotted := r.DecimalFormat()
+		if !RegexpLenient.MatchString(dotted) {
+			t.Fatal("lenient pattern rejects", dotted)
+		}
+
+		for _, dv := range "0123456789Kk" {
+			wrong := Rut(dotted[:len(dotted)-1] + string(dv))
+			if _, err := wrong.Validate(); err != nil && err != ErrinvalidDV {
+				t.Fatal(dotted, "matches but fails with", err)
+			}
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	for _, lenient := range []bool{false, true} {
+		schema := JSONSchema(lenient)
+		if _, err := regexp.Compile(schema["pattern"].(string)); err != nil {
+			t.Error(err)
+		}
+		if _, err := json.Marshal(schema); err != nil {
+			t.Error(err)
+		}
+	}
+	if JSONSchema(true)["pattern"] != PatternLenient || JSONSchema(false)["pattern"] != PatternStrict {
+		t.Error("unexpected pattern")
+	}
+}
@@ -0,0 +1,90 @@
+package rut
+
+import "errors"
+
+var (
+	ErrAboveMaxBody  = NewError("above_max_body", "'cuerpo' above the policy maximum")
+	ErrKindMismatch  = NewError("kind_mismatch", "rut of a kind the policy rejects")
+	ErrNotMachine    = NewError("not_machine_format", "rut not in the machine format 'NNNNNNNN-N' the policy requires")
+	ErrUnknownPolicy = errors.New("unknown policy, expected any, person, company, legacy or machine")
+)
+
+// Policy bundles the constraints a Validator enforces, the zero value
+// enforces none but the validity of the rut
+type Policy struct {
+	Name string
+
+	// MinLength and MaxLength bound the normalized length, zero uses
+	// MinRutlength and MaxRutlength
+	MinLength, MaxLength int
+
+	// MinBody and MaxBody, if set, bound the 'cuerpo' to [MinBody, MaxBody]
+	MinBody, MaxBody int
+
+	// Kind, unless KindAny, rejects the ruts of the other kind
+	Kind Kind
+
+	// MachineFormat rejects as ErrNotMachine the inputs other than the
+	// normalized form: no dots, no zero padding and an uppercase 'K'
+	MachineFormat bool
+}
+
+// Validation policy presets
+var (
+	// PolicyAny accepts the plausible ruts of persons and companies
+	PolicyAny = Policy{Name: "any", MinBody: 1000000}
+
+	// PolicyPerson accepts the plausible ruts of natural persons
+	PolicyPerson = Policy{Name: "person", MinBody: 1000000, Kind: KindPerson}
+
+	// PolicyCompany accepts the ruts of companies, 9 digit 'cuerpos' included
+	PolicyCompany = Policy{Name: "company", MaxLength: NineDigitRutlength, Kind: KindCompany}
+
+	// PolicyLegacy accepts the short 'cuerpos' of the old records, down to one digit
+	PolicyLegacy = Policy{Name: "legacy", MinLength: 3}
+
+	// PolicyMachine accepts the normalized form alone, '12345678-5',
+	// for the file interchange between systems
+	PolicyMachine = Policy{Name: "machine", MachineFormat: true}
+)
+
+var policies = []*Policy{&PolicyAny, &PolicyPerson, &PolicyCompany, &PolicyLegacy, &PolicyMachine}
+
+// ParsePolicy returns the preset named s
+func ParsePolicy(s string) (Policy, error) {
+	for _, p := range policies {
+		if p.Name == s {
+			return *p, nil
+		}
+	}
+	return Policy{}, ErrUnknownPolicy
+}
+
+// lengths returns the length bounds of the policy
+func (p *Policy) lengths() (minLen, maxLen int) {
+	minLen, maxLen = MinRutlength, MaxRutlength
+	if p.MinLength > 0 {
+		minLen = p.MinLength
+	}
+	if p.MaxLength > 0 {
+		maxLen = p.MaxLength
+	}
+	return
+}
+
+// check enforces the format, 'cuerpo' and kind constraints on a valid
+// rut, input is what r was normalized from
+func (p *Policy) check(input string, r Rut) error {
+	body := int(r.body())
+	switch {
+	case p.MachineFormat && (input != string(r) || r[0] == '0'):
+		return ErrNotMachine
+	case body < p.MinBody:
+		return ErrBelowMinBody
+	case p.MaxBody > 0 && body > p.MaxBody:
+		return ErrAboveMaxBody
+	case p.Kind != KindAny && kindOf(body) != p.Kind:
+		return ErrKindMismatch
+	}
+	return nil
+}
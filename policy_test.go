@@ -0,0 +1,48 @@
+package rut
+
+import "testing"
+
+func TestPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		policy Policy
+		input  string
+		err    error
+	}{
+		{PolicyAny, "12.345.678-5", nil},
+		{PolicyAny, "76.086.428-5", nil},
+		{PolicyAny, "12345678-0", ErrinvalidDV},
+		{PolicyPerson, "12.345.678-5", nil},
+		{PolicyPerson, "76.086.428-5", ErrKindMismatch},
+		{PolicyCompany, "76.086.428-5", nil},
+		{PolicyCompany, "123.456.789-2", nil},
+		{PolicyCompany, "12.345.678-5", ErrKindMismatch},
+		{PolicyAny, "123.456.789-2", ErrMaxLength},
+		{PolicyLegacy, "123-6", nil},
+		{PolicyAny, "123-6", ErrMinLength},
+		{Policy{MaxBody: 20000000}, "13.117.182-k", nil},
+		{Policy{MaxBody: 10000000}, "13.117.182-k", ErrAboveMaxBody},
+		{Policy{MinBody: 20000000}, "13.117.182-k", ErrBelowMinBody},
+		{PolicyMachine, "13117182-K", nil},
+		{PolicyMachine, "13117182-k", ErrNotMachine},
+		{PolicyMachine, "13.117.182-K", ErrNotMachine},
+		{PolicyMachine, "01234567-4", ErrNotMachine},
+		{PolicyMachine, "13117182-0", ErrinvalidDV},
+	} {
+		v := Validator{Policy: tc.policy}
+		res := v.Check(tc.input)
+		if res.Err != tc.err || (res.Err == nil) != (res.Rut != "") {
+			t.Error(tc.policy.Name, tc.input, "expected", tc.err, "got", res)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, name := range []string{"any", "person", "company", "legacy", "machine"} {
+		if p, err := ParsePolicy(name); err != nil || p.Name != name {
+			t.Error("unexpected policy", p, err)
+		}
+	}
+	if _, err := ParsePolicy("nobody"); err != ErrUnknownPolicy {
+		t.Error("expected ErrUnknownPolicy, got", err)
+	}
+}
@@ -0,0 +1,108 @@
+package rut
+
+import (
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// referenceValidate is the textbook mod 11, deliberately naive and
+// independent from the optimized implementation
+func referenceValidate(s string) (expected string, valid bool) {
+	s = strings.ReplaceAll(s, ".", "")
+	if len(s) < 3 || len(s) < MinRutlength || len(s) > MaxRutlength {
+		return
+	}
+	if s[len(s)-2] != '-' {
+		return
+	}
+
+	body, dv := s[:len(s)-2], strings.ToUpper(s[len(s)-1:])
+	if !strings.Contains("0123456789K", dv) {
+		return
+	}
+	sum, weight := new(big.Int), int64(2)
+	for i := len(body) - 1; i >= 0; i-- {
+		if body[i] < '0' || body[i] > '9' {
+			return
+		}
+		d := big.NewInt(int64(body[i] - '0'))
+		sum.Add(sum, d.Mul(d, big.NewInt(weight)))
+		if weight++; weight > 7 {
+			weight = 2
+		}
+	}
+
+	switch r := 11 - new(big.Int).Mod(sum, big.NewInt(11)).Int64(); r {
+	case 11:
+		expected = "0"
+	case 10:
+		expected = "K"
+	default:
+		expected = strconv.FormatInt(r, 10)
+	}
+	return expected, dv == expected
+}
+
+// adversarial builds inputs close to valid ruts
+func adversarial(r *rand.Rand) string {
+	const alphabet = "0123456789kK-. +x"
+
+	switch r.Intn(4) {
+	case 0:
+		// valid 'cuerpo' with a random dv
+		return strconv.Itoa(r.Intn(100000000)) + "-" + string(alphabet[r.Intn(12)])
+	case 1:
+		// generated rut, dotted
+		rut := GenerateRut(1, 100000000)
+		return rut.DecimalFormat()
+	case 2:
+		// generated rut with one mutated byte
+		rut := []byte(GenerateRut(1, 100000000))
+		rut[r.Intn(len(rut))] = alphabet[r.Intn(len(alphabet))]
+		return string(rut)
+	default:
+		// random garbage
+		b := make([]byte, r.Intn(14))
+		for i := range b {
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+}
+
+func TestDifferential(t *testing.T) {
+	n := 2000000
+	if testing.Short() {
+		n = 50000
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		s := adversarial(r)
+
+		expected, valid := referenceValidate(s)
+
+		rut := Rut(s)
+		ai, err := rut.Validate()
+		if valid != (err == nil) {
+			t.Fatalf("%q: reference valid %v, got %v", s, valid, err)
+		}
+		if expected != "" && (ai == nil || string(ai.ExpectedDV) != expected) {
+			t.Fatalf("%q: reference expected dv %s, got %v", s, expected, ai)
+		}
+	}
+}
+
+func TestDifferentialDV(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		body := r.Intn(100000000)
+		expected, _ := referenceValidate(strconv.Itoa(body) + "-0")
+		if expected != "" && string(computeDV(body)) != expected {
+			t.Fatalf("%d: reference expected dv %s, got %c", body, expected, computeDV(body))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package rut
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Repairs of RepairDV
+const (
+	RepairDVComputed  Repair = "dv_computed"
+	RepairDVCorrected Repair = "dv_corrected"
+)
+
+// RepairDV returns the valid rut of s, a 'cuerpo' alone, eg. '12.345.678',
+// gets its 'digito verificador' computed and a rut with the wrong one gets
+// it corrected. repair is "" for the ruts already valid
+func RepairDV(s string) (r Rut, repair Repair, err error) {
+	s = strings.TrimSpace(s)
+	if strings.IndexByte(s, dvseparator) < 0 {
+		digits := strings.TrimLeft(strings.Replace(s, ".", "", -1), "0")
+		if digits == "" || strings.Trim(digits, "0123456789") != "" {
+			return "", "", ErrExpectedDigit
+		}
+		if len(digits) > MaxRutlength-2 {
+			return "", "", ErrMaxLength
+		}
+		if r, err = FromBody(int(parseDigits(digits))); err != nil {
+			return "", "", err
+		}
+		return r, RepairDVComputed, nil
+	}
+
+	r = Rut(s)
+	info, err := r.Validate()
+	switch err {
+	case nil:
+		return
+	case ErrinvalidDV:
+		return fromBody(info.Body), RepairDVCorrected, nil
+	}
+	return "", "", err
+}
+
+// Change is a line RepairLines rewrote or failed to repair
+type Change struct {
+	Line   int
+	Input  string
+	Output Rut
+	Repair Repair
+
+	// Err is the reason the line was left unchanged
+	Err error
+}
+
+// RepairLines streams the lines of r to w applying RepairDV, the valid ruts
+// are written normalized and the lines failing unchanged, blank ones
+// included. fn, if set, receives every line changing its 'digito
+// verificador' and every failure. the report accounts the repaired ruts
+// and the failures, a huge line is one more failure
+func RepairLines(w io.Writer, r io.Reader, fn func(Change)) (report Report, err error) {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for line := 1; ; line++ {
+		var in string
+		in, err = br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		if err == io.EOF && in == "" {
+			return report, bw.Flush()
+		}
+		eof := err == io.EOF
+
+		in = strings.TrimRight(in, "\r\n")
+		out := in
+		if strings.TrimSpace(in) != "" {
+			res := Result{Input: in}
+			var repair Repair
+			if res.Rut, repair, res.Err = RepairDV(in); res.Err == nil {
+				out = string(res.Rut)
+			}
+			if fn != nil && (repair != "" || res.Err != nil) {
+				fn(Change{Line: line, Input: in, Output: res.Rut, Repair: repair, Err: res.Err})
+			}
+			report.Add(res)
+		}
+		if _, err = bw.WriteString(out + "\n"); err != nil {
+			return
+		}
+
+		if eof {
+			return report, bw.Flush()
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package rut
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRepairDV(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		rut    Rut
+		repair Repair
+		err    error
+	}{
+		{"12.345.678", "12345678-5", RepairDVComputed, nil},
+		{" 013117182 ", "13117182-K", RepairDVComputed, nil},
+		{"12.345.678-0", "12345678-5", RepairDVCorrected, nil},
+		{"12345678-5", "12345678-5", "", nil},
+		{"12345", "", "", ErrMinLength},
+		{"1234567890", "", "", ErrMaxLength},
+		{"12a45678", "", "", ErrExpectedDigit},
+		{"", "", "", ErrExpectedDigit},
+		{"12345678-x", "", "", ErrInvalidDVchar},
+	} {
+		r, repair, err := RepairDV(tc.in)
+		if r != tc.rut || repair != tc.repair || err != tc.err {
+			t.Errorf("%q: expected %q %q %v, got %q %q %v", tc.in, tc.rut, tc.repair, tc.err, r, repair, err)
+		}
+	}
+}
+
+func TestRepairLines(t *testing.T) {
+	in := "12345678\n\n11.111.111-1\n13117182-0\nfoo\n"
+
+	var (
+		out     bytes.Buffer
+		changes []Change
+	)
+	report, err := RepairLines(&out, strings.NewReader(in), func(c Change) {
+		changes = append(changes, c)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "12345678-5\n\n11111111-1\n13117182-K\nfoo\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+	expected := []Change{
+		{Line: 1, Input: "12345678", Output: "12345678-5", Repair: RepairDVComputed},
+		{Line: 4, Input: "13117182-0", Output: "13117182-K", Repair: RepairDVCorrected},
+		{Line: 5, Input: "foo", Err: ErrExpectedDigit},
+	}
+	if len(changes) != len(expected) {
+		t.Fatal("unexpected changes", changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Error("expected", expected[i], "got", changes[i])
+		}
+	}
+	if report.Total != 4 || report.Valid != 3 || report.Errors["expected_digit"] != 1 {
+		t.Error("unexpected report", report)
+	}
+}
+
+func TestRepairLinesLongLine(t *testing.T) {
+	long := strings.Repeat("1", 100000)
+	var out bytes.Buffer
+	report, err := RepairLines(&out, strings.NewReader("12345678\r\n"+long+"\n13117182-0"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "12345678-5\n" + long + "\n13117182-K\n"; out.String() != expected {
+		t.Error("unexpected output", len(out.String()))
+	}
+	if report.Total != 3 || report.Valid != 2 || report.Invalid != 1 {
+		t.Error("unexpected report", report)
+	}
+}
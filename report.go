@@ -0,0 +1,83 @@
+package rut
+
+// DefaultSampleSize is the number of failures a Report keeps
+// when SampleSize is unset
+const DefaultSampleSize = 10
+
+// Report summarizes a batch of validations,
+// the zero value is ready to use and it marshals to JSON
+type Report struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+
+	// Duplicates counts the valid ruts already seen, by normalized value
+	Duplicates int `json:"duplicates"`
+
+	// Errors counts the failures by error Code
+	Errors map[string]int `json:"errors,omitempty"`
+
+	// Samples are the first SampleSize failures
+	Samples []Sample `json:"samples,omitempty"`
+
+	// SampleSize defaults to DefaultSampleSize, negative keeps no samples
+	SampleSize int `json:"-"`
+
+	seen bodyset
+}
+
+// Sample is a failed validation kept by a Report
+type Sample struct {
+	// Index is the position of the input among the processed ones, from 1
+	Index int    `json:"index"`
+	Input string `json:"input"`
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// NewReport returns the Report of results
+func NewReport(results ...Result) *Report {
+	rp := new(Report)
+	for _, res := range results {
+		rp.Add(res)
+	}
+	return rp
+}
+
+// Add accounts a result
+func (rp *Report) Add(res Result) {
+	rp.Total++
+
+	if res.Err == nil {
+		rp.addValid(res.Rut.body())
+		return
+	}
+
+	rp.Invalid++
+	code := Code(res.Err)
+	if rp.Errors == nil {
+		rp.Errors = make(map[string]int)
+	}
+	rp.Errors[code]++
+
+	size := rp.SampleSize
+	if size == 0 {
+		size = DefaultSampleSize
+	}
+	if len(rp.Samples) < size {
+		rp.Samples = append(rp.Samples, Sample{
+			Index: rp.Total,
+			Input: res.Input,
+			Code:  code,
+			Error: res.Err.Error(),
+		})
+	}
+}
+
+// addValid accounts a valid rut by 'cuerpo', Total excluded
+func (rp *Report) addValid(body uint64) {
+	rp.Valid++
+	if !rp.seen.set(body) {
+		rp.Duplicates++
+	}
+}
@@ -0,0 +1,30 @@
+package rut
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	rp := &Report{SampleSize: 1}
+	for _, s := range []string{"12.345.678-5", "12345678-5", "12345678-0", "1-9", "11111111-1", "x"} {
+		rp.Add(Check(s))
+	}
+
+	if all := NewReport(ValidateBatch(context.Background(), []string{"1-9", "11111111-1"}, 1)...); all.Total != 2 || len(all.Samples) != 1 {
+		t.Error("unexpected report", all)
+	}
+
+	b, err := json.Marshal(rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"total":6,"valid":3,"invalid":3,"duplicates":1,` +
+		`"errors":{"invalid_dv":1,"min_length":2},` +
+		`"samples":[{"index":3,"input":"12345678-0","code":"invalid_dv","error":"invalid 'digito verificador'"}]}`
+	if string(b) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b)
+	}
+}
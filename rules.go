@@ -0,0 +1,87 @@
+package rut
+
+var (
+	ErrNotAllowed = NewError("not_allowed", "'cuerpo' outside the allowed ranges")
+	ErrBlocked    = NewError("blocked", "rut in the blocklist")
+)
+
+// Rule is a business rule the Validator applies to the valid ruts,
+// see Validator.Rules
+type Rule interface {
+	// Name identifies the rule in a RuleError
+	Name() string
+
+	// Check returns why the valid rut r breaks the rule, nil if it doesn't
+	Check(r Rut) error
+}
+
+// RuleError is the error of the first Rule a rut breaks, errors.Is and
+// Code see through it to the rule error
+type RuleError struct {
+	Rule string
+	Err  error
+}
+
+func (e *RuleError) Error() string {
+	return "rule " + e.Rule + ": " + e.Err.Error()
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// funcRule is a Rule made of a name and a function
+type funcRule struct {
+	name  string
+	check func(Rut) error
+}
+
+func (f funcRule) Name() string      { return f.name }
+func (f funcRule) Check(r Rut) error { return f.check(r) }
+
+// NewRule returns the Rule named name checked by check
+func NewRule(name string, check func(r Rut) error) Rule {
+	return funcRule{name, check}
+}
+
+// AllowRanges returns the Rule "allow_ranges" rejecting as ErrNotAllowed
+// the ruts whose 'cuerpo' isn't in any of the [min, max] ranges
+func AllowRanges(ranges ...[2]int) Rule {
+	return NewRule("allow_ranges", func(r Rut) error {
+		body := int(r.body())
+		for _, rg := range ranges {
+			if rg[0] <= body && body <= rg[1] {
+				return nil
+			}
+		}
+		return ErrNotAllowed
+	})
+}
+
+// Blocklist returns the Rule "blocklist" rejecting the ruts as ErrBlocked,
+// the invalid ones are ignored
+func Blocklist(ruts ...Rut) Rule {
+	blocked := make(map[uint64]bool, len(ruts))
+	for _, r := range ruts {
+		if _, err := r.Validate(); err == nil {
+			blocked[r.body()] = true
+		}
+	}
+	return NewRule("blocklist", func(r Rut) error {
+		if blocked[r.body()] {
+			return ErrBlocked
+		}
+		return nil
+	})
+}
+
+// KindRule returns the Rule "kind" rejecting the ruts of the other kind
+// as ErrKindMismatch
+func KindRule(k Kind) Rule {
+	return NewRule("kind", func(r Rut) error {
+		if kindOf(int(r.body())) != k {
+			return ErrKindMismatch
+		}
+		return nil
+	})
+}
@@ -0,0 +1,51 @@
+package rut
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	v := Validator{Rules: []Rule{
+		AllowRanges([2]int{10000000, 20000000}, [2]int{76000000, 77000000}),
+		Blocklist("11.111.111-1", "12345678-0"),
+		KindRule(KindPerson),
+		NewRule("no_k", func(r Rut) error {
+			if r[len(r)-1] == 'K' {
+				return errors.New("no K please")
+			}
+			return nil
+		}),
+	}}
+
+	for input, expected := range map[string]struct {
+		rule string
+		err  error
+	}{
+		"12.345.678-5": {},
+		"5.126.663-3":  {"allow_ranges", ErrNotAllowed},
+		"11111111-1":   {"blocklist", ErrBlocked},
+		"76.086.428-5": {"kind", ErrKindMismatch},
+		"13117182-k":   {"no_k", nil},
+	} {
+		_, err := v.Validate(input)
+		if expected.rule == "" {
+			if err != nil {
+				t.Error(input, "unexpected error", err)
+			}
+			continue
+		}
+		var re *RuleError
+		if !errors.As(err, &re) || re.Rule != expected.rule || (expected.err != nil && !errors.Is(err, expected.err)) {
+			t.Error(input, "expected", expected, "got", err)
+		}
+	}
+
+	_, err := v.Validate("11111111-1")
+	if err.Error() != "rule blocklist: rut in the blocklist" || Code(err) != "blocked" {
+		t.Error("unexpected error", err, Code(err))
+	}
+	if _, err := v.Validate("12345678-0"); err != ErrinvalidDV {
+		t.Error("expected the rules to apply to valid ruts only, got", err)
+	}
+}
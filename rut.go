@@ -1,18 +1,23 @@
 /*
 Package rut validates and generates 'Rol Único Tributario'
 https://en.wikipedia.org/wiki/National_identification_number#Chile
+
+Rut implements encoding.TextUnmarshaler, so a Rut field decoded by
+encoding/json, flag or the config libraries is validated and holds the
+normalized form, eg. "12.345.678-5" decodes as "12345678-5". use a
+string field to keep the input as is
 Alvaro Leiva M.
 https://github.com/alvarolm
 */
 package rut
 
 import (
-	"errors"
 	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
+
+	"github.com/alvarolm/rut/rutcore"
 )
 
 const (
@@ -20,21 +25,46 @@ const (
 )
 
 var (
-	ErrMinLength     = errors.New("length less than expected")
-	ErrMaxLength     = errors.New("exceeded max length")
-	ErrNoDVSeparator = errors.New("no valid 'digito verificador' separator: '-'")
-	ErrInvalidDVchar = errors.New("expected digit or 'K' as 'digito verificador', instead found invalid character")
-	ErrExpectedDigit = errors.New("expected digit in 'cuerpo', instead found invalid character")
-	ErrinvalidDV     = errors.New("invalid 'digito verificador'")
+	ErrMinLength     = NewError("min_length", "length less than expected")
+	ErrMaxLength     = NewError("max_length", "exceeded max length")
+	ErrNoDVSeparator = NewError("no_dv_separator", "no valid 'digito verificador' separator: '-'")
+	ErrInvalidDVchar = NewError("invalid_dv_char", "expected digit or 'K' as 'digito verificador', instead found invalid character")
+	ErrExpectedDigit = NewError("expected_digit", "expected digit in 'cuerpo', instead found invalid character")
+	ErrinvalidDV     = NewError("invalid_dv", "invalid 'digito verificador'")
 )
 
 // Rut implements 'Rol Único Tributario' formatting and validation
 type Rut string
 
+// String returns the rut as is, "" for nil
 func (r *Rut) String() string {
+	if r == nil {
+		return ""
+	}
 	return string(*r)
 }
 
+// Key returns the normalized form of the rut without its 'cuerpo' zero
+// padding and without modifying it, or "" when it's invalid. every
+// spelling of a rut ('12.345.678-5', '12345678-5', '01.234.567-4' and
+// '1234567-4') has the same Key, use it instead of the raw value as the
+// key of maps and caches
+func (r *Rut) Key() string {
+	if r == nil {
+		return ""
+	}
+	k := *r
+	if _, err := k.Validate(); err != nil {
+		return ""
+	}
+	return strconv.FormatUint(k.body(), 10) + string(k[len(k)-2:])
+}
+
+// IsZero reports whether r is nil or empty
+func (r *Rut) IsZero() bool {
+	return r == nil || *r == ""
+}
+
 var (
 	// NNNNNNN-N
 	MinRutlength = 9
@@ -43,23 +73,45 @@ var (
 	MaxRutlength = 10
 )
 
+// NineDigitRutlength is the length of the ruts with a 9 digit 'cuerpo',
+// NNNNNNNNN-N, set it as MaxRutlength or use Validator.NineDigitBodies
+// to accept the 'cuerpos' past 99.999.999. the formatters accept it
+// whatever MaxRutlength is, so the ruts of NineDigitBodies render, Body and
+// the encodings built on it keep to MaxRutlength as their inverses do
+const NineDigitRutlength = 11
+
+// renderLength is the MaxRutlength of the formatters
+func renderLength() int {
+	return max(MaxRutlength, NineDigitRutlength)
+}
+
 func NewRut(nid string) *Rut {
 	rut := Rut(nid)
 	return &rut
 }
 
+// Parse validates nid and returns its normalized form
+func Parse(nid string) (Rut, error) {
+	rut := Rut(nid)
+	if _, err := rut.Validate(); err != nil {
+		return "", err
+	}
+	return rut, nil
+}
+
 // format checks basic formatting constraints
-// 'NNNN...-(N || K)'
-func (r *Rut) format() (err error) {
+// 'NNNN...-(N || K)', minLen and maxLen bound the length
+func (r *Rut) format(minLen, maxLen int) (err error) {
 
 	// removes point decimal points if has some
 	*r = Rut(strings.Replace(string(*r), ".", "", -1))
 
 	length := len(*r)
 
-	if length < MinRutlength {
+	// at least one 'cuerpo' digit whatever MinRutlength is set to
+	if length < minLen || length < 3 {
 		return ErrMinLength
-	} else if length > MaxRutlength {
+	} else if length > maxLen {
 		return ErrMaxLength
 	}
 
@@ -79,130 +131,213 @@ func (r *Rut) format() (err error) {
 		}
 	}
 
-	body := string(*r)[:length-2]
-
-	if _, err = strconv.Atoi(body); err != nil {
-		return ErrExpectedDigit
-	}
-
+	// 'cuerpo' digits are checked while validating
 	return
 }
 
-type AdittionalValidationInfo struct {
+// ValidationInfo is what Validate learns of a rut
+type ValidationInfo struct {
+	// ExpectedDV is the 'digito verificador' the 'cuerpo' requires
 	ExpectedDV rune
+
+	// Body is the numeric 'cuerpo'
+	Body int
+
+	// Normalized is the normalized form, empty when the rut isn't valid
+	Normalized Rut
 }
 
-// Validate performs formatting and ecc validation (digito verificador)
-func (r *Rut) Validate() (additionalinfo *AdittionalValidationInfo, err error) {
+// AdittionalValidationInfo is the former name of ValidationInfo
+//
+// Deprecated: use ValidationInfo
+type AdittionalValidationInfo = ValidationInfo
 
-	if err = r.format(); err != nil {
-		return
-	}
+// Validate performs formatting and ecc validation (digito verificador),
+// info is nil when the rut is too malformed to compute its 'digito verificador'
+func (r *Rut) Validate() (info *ValidationInfo, err error) {
+	return r.validate(MinRutlength, MaxRutlength)
+}
 
-	length := len(*r)
-	body := string(*r)[:length-2]
-	bodylastindex := len(body) - 1
+// validate is Validate with the given length bounds
+func (r *Rut) validate(minLen, maxLen int) (info *ValidationInfo, err error) {
 
-	multsequence := []int{2, 3, 4, 5, 6, 7}
-	currentmultpos := 0
+	// nil is as invalid as the zero value
+	if r == nil {
+		err = ErrMinLength
+		return
+	}
 
-	nextmult := func() (mult int) {
-		mult = multsequence[currentmultpos]
-		if currentmultpos == 5 {
-			currentmultpos = 0
-		} else {
-			currentmultpos++
-		}
+	if err = r.format(minLen, maxLen); err != nil {
 		return
 	}
 
+	length := len(*r)
+	body := string(*r)[:length-2]
+
 	// validate
-	var productssum int
-	for i := range body {
-		d := rune(body[bodylastindex-i])
-		switch d {
-		case '0':
-			nextmult() // pass
-		case '1':
-			productssum += (1 * nextmult())
-		case '2':
-			productssum += (2 * nextmult())
-		case '3':
-			productssum += (3 * nextmult())
-		case '4':
-			productssum += (4 * nextmult())
-		case '5':
-			productssum += (5 * nextmult())
-		case '6':
-			productssum += (6 * nextmult())
-		case '7':
-			productssum += (7 * nextmult())
-		case '8':
-			productssum += (8 * nextmult())
-		case '9':
-			productssum += (9 * nextmult())
-		default:
-			err = ErrExpectedDigit
-			return
-		}
+	expected, ok := rutcore.DVString(body)
+	if !ok {
+		err = ErrExpectedDigit
+		return
 	}
 
-	additionalinfo = &AdittionalValidationInfo{}
-
-	switch m11 := 11 - (productssum % 11); m11 {
-	case 11:
-		additionalinfo.ExpectedDV = '0'
-	case 10:
-		additionalinfo.ExpectedDV = 'K'
-	default:
-		additionalinfo.ExpectedDV = rune(strconv.FormatInt(int64(m11), 10)[0])
+	info = &ValidationInfo{
+		ExpectedDV: rune(expected),
+		Body:       int(r.body()),
 	}
 
 	dv := rune(string(*r)[length-1])
 
-	if additionalinfo.ExpectedDV != dv {
+	if info.ExpectedDV != dv {
 		err = ErrinvalidDV
 		return
 	}
 
+	info.Normalized = *r
 	return
 }
 
+// ValidInt validates a numeric 'cuerpo' and 'digito verificador' pair
+// without building strings, it agrees with Validate on the equivalent rut
+// including the MinRutlength and MaxRutlength constraints
+func ValidInt(body int64, dv byte) bool {
+	if body < 0 {
+		return false
+	}
+
+	// same length constraints as the formatted 'NNNN...-N'
+	length := 2
+	for b := body; b > 0; b /= 10 {
+		length++
+	}
+	if length < MinRutlength || length < 3 || length > MaxRutlength {
+		return false
+	}
+
+	if dv == 'k' {
+		dv = 'K'
+	}
+	return byte(computeDV(int(body))) == dv
+}
+
 // DecimalFormat returns a decimal point version
-// safe to call after validation
-// * panics with an unexpected format
+// safe to call after validation, nil and the zero value return ""
+//
+// Deprecated: use DecimalFormatSafe or Format, they validate the rut first
 func (r *Rut) DecimalFormat() string {
-	parts := strings.Split(r.String(), string(dvseparator))
-	d, _ := strconv.ParseInt(parts[0], 10, 64)
-	return punto(d) + string(dvseparator) + parts[1]
+	return r.decimalFormat()
+}
+
+// DecimalFormatSafe validates the rut and returns its decimal point version
+func (r *Rut) DecimalFormatSafe() (string, error) {
+	if _, err := r.validate(MinRutlength, renderLength()); err != nil {
+		return "", err
+	}
+	return r.decimalFormat(), nil
+}
+
+// decimalFormat is DecimalFormat, the rut must be valid
+func (r *Rut) decimalFormat() string {
+	return r.grouped(".", false)
+}
+
+// grouped renders the rut with the 'cuerpo' thousands grouped by sep and,
+// if masked, its digits but the last three replaced by '*'.
+// the rut must be valid, nil and too short ruts return ""
+func (r *Rut) grouped(sep string, masked bool) string {
+	if r == nil || len(*r) < 3 {
+		return ""
+	}
+	body, dv := string(*r)[:len(*r)-2], string(*r)[len(*r)-2:]
+	out := make([]byte, 0, len(*r)+len(sep)*(len(body)/3))
+	if masked {
+		hidden := len(body) - 3
+		if hidden < 0 {
+			hidden = 0
+		}
+		body = strings.Repeat("*", hidden) + body[hidden:]
+	}
+	out = appendThousands(out, body, sep)
+	return string(append(out, dv...))
+}
+
+// MaskedFormat returns a decimal point version with every 'cuerpo' digit
+// but the last three replaced by '*', eg. '**.***.678-5'
+// safe to call after validation, nil and the zero value return ""
+func (r *Rut) MaskedFormat() string {
+	return r.grouped(".", true)
 }
 
+// GenerateRut returns a valid rut with a 'cuerpo' in [min, max)
+// the global math/rand source is used, it's randomly seeded since go 1.20
 func GenerateRut(min, max int) (rut Rut) {
-	rand.Seed(time.Now().UnixNano())
-	rut = Rut(strconv.Itoa(rand.Intn(max-min)+min) + "-0")
-	ai, _ := rut.Validate()
-	rut = Rut(string(rut)[:len(rut)-1] + string(ai.ExpectedDV))
-	return
+	return fromBody(rand.Intn(max-min) + min)
 }
 
 // helpers
 
-func punto(v int64) string {
+// Body validates the rut and returns its numeric 'cuerpo'
+func (r *Rut) Body() (int, error) {
+	if _, err := r.Validate(); err != nil {
+		return 0, err
+	}
+	return int(r.body()), nil
+}
+
+// FromBody returns the valid rut of a 'cuerpo',
+// the rut must satisfy the MinRutlength and MaxRutlength constraints
+func FromBody(body int) (Rut, error) {
+	if body < 0 {
+		return "", ErrExpectedDigit
+	}
+	rut := fromBody(body)
+	if len(rut) < MinRutlength {
+		return "", ErrMinLength
+	} else if len(rut) > MaxRutlength {
+		return "", ErrMaxLength
+	}
+	return rut, nil
+}
+
+// FromParts validates a 'cuerpo' and 'digito verificador' stored apart,
+// eg. in two database columns, and returns their normalized rut
+func FromParts(body string, dv byte) (Rut, error) {
+	return Parse(body + string(dvseparator) + string(dv))
+}
 
-	parts := []string{"", "", "", "", "", "", ""}
-	j := len(parts) - 1
+// Split validates the rut and returns its normalized 'cuerpo' and
+// 'digito verificador', the inverse of FromParts
+func (r *Rut) Split() (body string, dv byte, err error) {
+	if _, err = r.Validate(); err != nil {
+		return
+	}
+	return string((*r)[:len(*r)-2]), (*r)[len(*r)-1], nil
+}
+
+// body returns the numeric 'cuerpo'
+// safe to call after validation
+func (r *Rut) body() uint64 {
+	body, _ := strconv.ParseUint(string((*r)[:len(*r)-2]), 10, 64)
+	return body
+}
+
+// fromBody builds the canonical rut of a 'cuerpo'
+func fromBody(body int) Rut {
+	return Rut(strconv.Itoa(body) + string(dvseparator) + string(computeDV(body)))
+}
+
+// computeDV computes the 'digito verificador' of a 'cuerpo' without formatting it
+func computeDV(body int) rune {
+	return rune(rutcore.DV(uint64(body)))
+}
 
-	for v > 999 {
-		parts[j] = strconv.FormatInt(v%1000, 10)
-		switch len(parts[j]) {
-		case 2:
-			parts[j] = "0" + parts[j]
-		case 1:
-			parts[j] = "00" + parts[j]
+// appendThousands appends digits grouped in thousands by sep, '12345678' -> '12.345.678'
+func appendThousands[S ~string | ~[]byte](dst []byte, digits S, sep string) []byte {
+	for i := 0; i < len(digits); i++ {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			dst = append(dst, sep...)
 		}
-		v = v / 1000
-		j--
+		dst = append(dst, digits[i])
 	}
-	parts[j] = strconv.Itoa(int(v))
-	return strings.Join(parts[j:], ".")
+	return dst
 }
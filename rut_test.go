@@ -1,7 +1,10 @@
 package rut
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -25,3 +28,353 @@ func TestGenerarRut(t *testing.T) {
 		fmt.Println(generatedRut)
 	}
 }
+
+func TestMaskedFormat(t *testing.T) {
+	for in, expected := range map[string]string{
+		"12345678-5": "**.***.678-5",
+		"1234567-4":  "*.***.567-4",
+	} {
+		rut := Rut(in)
+		if got := rut.MaskedFormat(); got != expected {
+			t.Error("expected", expected, "got", got)
+		}
+	}
+}
+
+func TestComputeDV(t *testing.T) {
+	for body := 1000000; body < 1100000; body++ {
+		rut := Rut(strconv.Itoa(body) + "-0")
+		ai, _ := rut.Validate()
+		if got := computeDV(body); got != ai.ExpectedDV {
+			t.Fatal(body, "expected", string(ai.ExpectedDV), "got", string(got))
+		}
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateRut(5000000, 23000000)
+	}
+}
+
+func BenchmarkGenerateBulk(b *testing.B) {
+	ruts := make([]Rut, 10000)
+	for i := 0; i < b.N; i++ {
+		for j := range ruts {
+			ruts[j] = GenerateRut(5000000, 23000000)
+		}
+	}
+}
+
+func TestShortInputs(t *testing.T) {
+	defer func(min int) { MinRutlength = min }(MinRutlength)
+	MinRutlength = 0
+
+	for _, s := range []string{"", "-", "1", "1-", "-1"} {
+		rut := Rut(s)
+		if _, err := rut.Validate(); err != ErrMinLength {
+			t.Error(s, "expected", ErrMinLength, "got", err)
+		}
+	}
+}
+
+func TestZeroPadding(t *testing.T) {
+	rut, err := Parse("01.234.567-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rut != "01234567-4" || rut.Key() != "1234567-4" {
+		t.Error("unexpected rut", rut, rut.Key())
+	}
+
+	// the padding counts towards MaxRutlength
+	for _, s := range []string{"012345678-5", "0000000000000012345678-5"} {
+		if _, err := Parse(s); err != ErrMaxLength {
+			t.Error(s, "expected", ErrMaxLength, "got", err)
+		}
+	}
+	if rut, err := Parse("0000000-0"); err != nil || rut != "0000000-0" {
+		t.Error("unexpected rut", rut, err)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	ruts := []Rut{"11111111-1", "12345678-5", "5126663-3", "13117182-K", "9829843-6"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rut := ruts[i%len(ruts)]
+		if _, err := rut.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestValidInt(t *testing.T) {
+	for body := int64(0); body < 20000000; body += 997 {
+		for _, dv := range []byte("0123456789Kk") {
+			rut := Rut(strconv.FormatInt(body, 10) + "-" + string(dv))
+			_, err := rut.Validate()
+			if got := ValidInt(body, dv); got != (err == nil) {
+				t.Fatal(rut, "expected", err == nil, "got", got)
+			}
+		}
+	}
+
+	if ValidInt(-12345678, '5') {
+		t.Error("negative 'cuerpo' reported as valid")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { ValidInt(12345678, '5') }); allocs != 0 {
+		t.Error("expected no allocations, got", allocs)
+	}
+}
+
+func TestBody(t *testing.T) {
+	rut := Rut("12.345.678-5")
+	if body, err := rut.Body(); err != nil || body != 12345678 {
+		t.Error("expected 12345678, got", body, err)
+	}
+
+	rut = Rut("12.345.678-0")
+	if _, err := rut.Body(); err != ErrinvalidDV {
+		t.Error("expected", ErrinvalidDV, "got", err)
+	}
+
+	if r, err := FromBody(13117182); err != nil || r != "13117182-K" {
+		t.Error("expected 13117182-K, got", r, err)
+	}
+	if _, err := FromBody(123); err != ErrMinLength {
+		t.Error("expected", ErrMinLength, "got", err)
+	}
+	if _, err := FromBody(123456789); err != ErrMaxLength {
+		t.Error("expected", ErrMaxLength, "got", err)
+	}
+}
+
+func TestFromParts(t *testing.T) {
+	if r, err := FromParts("13.117.182", 'k'); err != nil || r != "13117182-K" {
+		t.Error("expected 13117182-K, got", r, err)
+	}
+	if _, err := FromParts("12345678", '0'); err != ErrinvalidDV {
+		t.Error("expected", ErrinvalidDV, "got", err)
+	}
+	if _, err := FromParts("123-5678", '5'); err != ErrExpectedDigit {
+		t.Error("expected", ErrExpectedDigit, "got", err)
+	}
+	if _, err := FromParts("", '5'); err != ErrMinLength {
+		t.Error("expected", ErrMinLength, "got", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	rut := Rut("13.117.182-k")
+	body, dv, err := rut.Split()
+	if err != nil || body != "13117182" || dv != 'K' {
+		t.Error("expected 13117182 K, got", body, dv, err)
+	}
+	if r, err := FromParts(body, dv); err != nil || r != rut {
+		t.Error("expected", rut, "got", r, err)
+	}
+
+	rut = Rut("12.345.678-0")
+	if body, dv, err := rut.Split(); err != ErrinvalidDV || body != "" || dv != 0 {
+		t.Error("expected", ErrinvalidDV, "got", body, dv, err)
+	}
+}
+
+func TestDecimalFormatSafe(t *testing.T) {
+	for input, expected := range map[string]string{
+		"11111111-1":   "11.111.111-1",
+		"12.345.678-5": "12.345.678-5",
+		"5126663-3":    "5.126.663-3",
+		"13117182-k":   "13.117.182-K",
+	} {
+		r := Rut(input)
+		if got, err := r.DecimalFormatSafe(); err != nil || got != expected {
+			t.Error(input, "expected", expected, "got", got, err)
+		}
+	}
+
+	for _, input := range []string{"", "-", "12345678", "12345678-0", "abc-1", "........"} {
+		r := Rut(input)
+		if got, err := r.DecimalFormatSafe(); err == nil || got != "" {
+			t.Error(input, "expected an error, got", got)
+		}
+	}
+}
+
+func TestAppendThousands(t *testing.T) {
+	for digits, expected := range map[string]string{
+		"":              "",
+		"1":             "1",
+		"12":            "12",
+		"123":           "123",
+		"1234":          "1.234",
+		"123456":        "123.456",
+		"1234567":       "1.234.567",
+		"12345678":      "12.345.678",
+		"123456789":     "123.456.789",
+		"1234567890123": "1.234.567.890.123",
+	} {
+		if got := string(appendThousands(nil, digits, ".")); got != expected {
+			t.Error(digits, "expected", expected, "got", got)
+		}
+	}
+}
+
+func TestDecimalFormatAnyLength(t *testing.T) {
+	defer func(min, max int) { MinRutlength, MaxRutlength = min, max }(MinRutlength, MaxRutlength)
+	MinRutlength, MaxRutlength = 3, 14
+
+	for body, expected := range map[int]string{
+		1:            "1",
+		12:           "12",
+		123:          "123",
+		1000:         "1.000",
+		100000000:    "100.000.000",
+		123456789012: "123.456.789.012",
+	} {
+		r := fromBody(body)
+		got, err := r.DecimalFormatSafe()
+		if err != nil {
+			t.Fatal(body, err)
+		}
+		if expected += "-" + string(computeDV(body)); got != expected {
+			t.Error(body, "expected", expected, "got", got)
+		}
+	}
+}
+
+func TestNilAndZero(t *testing.T) {
+	var nilrut *Rut
+	var zero Rut
+
+	if !nilrut.IsZero() || !zero.IsZero() {
+		t.Error("expected IsZero")
+	}
+	if r := Rut("1"); r.IsZero() {
+		t.Error("unexpected IsZero")
+	}
+
+	for _, r := range []*Rut{nilrut, &zero} {
+		if r.String() != "" || r.DecimalFormat() != "" || r.MaskedFormat() != "" {
+			t.Error("expected empty formatting")
+		}
+		if _, err := r.Validate(); err != ErrMinLength {
+			t.Error("expected ErrMinLength, got", err)
+		}
+		if s, err := r.Format(FormatOptions{Style: StyleDotted}); s != "" || err != ErrMinLength {
+			t.Error("expected ErrMinLength, got", s, err)
+		}
+		if _, err := r.Body(); err != ErrMinLength {
+			t.Error("expected ErrMinLength, got", err)
+		}
+		if _, err := r.Kind(); err != ErrMinLength {
+			t.Error("expected ErrMinLength, got", err)
+		}
+	}
+
+	for _, r := range []Rut{"-", "1-", "K"} {
+		if r.DecimalFormat() != "" || r.MaskedFormat() != "" {
+			t.Error(r, "expected empty formatting")
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	spellings := []Rut{"13117182-K", "13.117.182-K", "13.117.182-k", "13117182-k", "13.117182-K"}
+	for _, r := range spellings {
+		before := r
+		if k := r.Key(); k != "13117182-K" {
+			t.Error(r, "expected 13117182-K, got", k)
+		}
+		if r != before {
+			t.Error("Key modified the rut", before, r)
+		}
+	}
+
+	// keying a map by Key merges the spellings, keying it by the raw value doesn't
+	byKey, byRaw := map[string]int{}, map[Rut]int{}
+	for _, r := range spellings {
+		byKey[r.Key()]++
+		byRaw[r]++
+	}
+	if len(byKey) != 1 || len(byRaw) != len(spellings) {
+		t.Error("unexpected map sizes", len(byKey), len(byRaw))
+	}
+
+	for _, r := range []Rut{"", "13117182-0", "13117182", "x"} {
+		if k := r.Key(); k != "" {
+			t.Error(r, "expected no key, got", k)
+		}
+	}
+	var nilrut *Rut
+	if nilrut.Key() != "" {
+		t.Error("expected no key for nil")
+	}
+}
+
+func TestKeyIsIdempotent(t *testing.T) {
+	for body := 1000000; body < 30000000; body += 104729 {
+		r := fromBody(body)
+		dotted := Rut(r.decimalFormat())
+		k := Rut(dotted.Key())
+		if k != r || k.Key() != string(k) {
+			t.Fatal(dotted, "unexpected key", k)
+		}
+	}
+}
+
+func TestValidationInfo(t *testing.T) {
+	r := Rut("12.345.678-5")
+	info, err := r.Validate()
+	if err != nil || *info != (ValidationInfo{ExpectedDV: '5', Body: 12345678, Normalized: "12345678-5"}) {
+		t.Error("unexpected info", info, err)
+	}
+
+	r = "12.345.678-0"
+	info, err = r.Validate()
+	if err != ErrinvalidDV || *info != (ValidationInfo{ExpectedDV: '5', Body: 12345678}) {
+		t.Error("unexpected info", info, err)
+	}
+
+	// the deprecated name is the same type
+	var old *AdittionalValidationInfo = info
+	if old.ExpectedDV != '5' {
+		t.Error("unexpected info", old)
+	}
+}
+
+func TestLargeBodies(t *testing.T) {
+	defer func(min, max int) { MinRutlength, MaxRutlength = min, max }(MinRutlength, MaxRutlength)
+	MinRutlength, MaxRutlength = 3, 14
+
+	// same low 32 bits
+	a, b := fromBody(123456789012), fromBody(123456789012+1<<32)
+
+	if body, err := a.Body(); err != nil || body != 123456789012 {
+		t.Error("unexpected body", body, err)
+	}
+	if s, err := a.Format(FormatOptions{Style: StyleDotted}); err != nil || s != "123.456.789.012-"+string(computeDV(123456789012)) {
+		t.Error("unexpected format", s, err)
+	}
+
+	if rp := NewReport(Check(string(a)), Check(string(b)), Check(string(a))); rp.Duplicates != 1 {
+		t.Error("expected 1 duplicate, got", rp.Duplicates)
+	}
+	if _, _, both := Diff(slices.Values([]Rut{a}), slices.Values([]Rut{b})); both != nil {
+		t.Error("unexpected both", both)
+	}
+
+	v := Validator{MinBody: 123456789013, Rules: []Rule{Blocklist(a)}}
+	if _, err := v.Validate(string(a)); err != ErrBelowMinBody {
+		t.Error("expected ErrBelowMinBody, got", err)
+	}
+	v.MinBody = 0
+	if _, err := v.Validate(string(a)); !errors.Is(err, ErrBlocked) {
+		t.Error("expected ErrBlocked, got", err)
+	}
+	if _, err := v.Validate(string(b)); err != nil {
+		t.Error("unexpected error", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Package rutbson stores ruts in MongoDB documents as strings in the
+canonical 'NNNNNNNN-D' form, validating them both ways. use RUT as the
+type of the document fields, or Register the codec of rut.Rut
+
+	reg := bson.NewRegistry()
+	rutbson.Register(reg)
+	client, err := mongo.Connect(options.Client().ApplyURI(uri).SetRegistry(reg))
+
+empty ruts are stored as null, and null decodes as them
+*/
+package rutbson
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/x/bsonx/bsoncore"
+
+	"github.com/alvarolm/rut"
+)
+
+// RUT is a rut.Rut implementing bson.ValueMarshaler and bson.ValueUnmarshaler
+type RUT rut.Rut
+
+// MarshalBSONValue validates the rut and marshals its normalized form
+func (r RUT) MarshalBSONValue() (byte, []byte, error) {
+	if r == "" {
+		return byte(bson.TypeNull), nil, nil
+	}
+	p, err := rut.Parse(string(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return byte(bson.TypeString), bsoncore.AppendString(nil, string(p)), nil
+}
+
+// UnmarshalBSONValue validates and normalizes a string or null value
+func (r *RUT) UnmarshalBSONValue(typ byte, data []byte) error {
+	switch bson.Type(typ) {
+	case bson.TypeNull:
+		*r = ""
+		return nil
+	case bson.TypeString:
+		s, _, ok := bsoncore.ReadString(data)
+		if !ok {
+			return fmt.Errorf("rutbson: malformed string value")
+		}
+		p, err := rut.Parse(s)
+		if err != nil {
+			return err
+		}
+		*r = RUT(p)
+		return nil
+	}
+	return fmt.Errorf("rutbson: cannot decode %v into a rut", bson.Type(typ))
+}
+
+// Register adds the encoder and decoder of rut.Rut to reg, they behave as
+// the RUT methods
+func Register(reg *bson.Registry) {
+	t := reflect.TypeOf(rut.Rut(""))
+	reg.RegisterTypeEncoder(t, bson.ValueEncoderFunc(encode))
+	reg.RegisterTypeDecoder(t, bson.ValueDecoderFunc(decode))
+}
+
+func encode(_ bson.EncodeContext, vw bson.ValueWriter, v reflect.Value) error {
+	if v.String() == "" {
+		return vw.WriteNull()
+	}
+	p, err := rut.Parse(v.String())
+	if err != nil {
+		return err
+	}
+	return vw.WriteString(string(p))
+}
+
+func decode(_ bson.DecodeContext, vr bson.ValueReader, v reflect.Value) error {
+	switch vr.Type() {
+	case bson.TypeNull:
+		v.SetString("")
+		return vr.ReadNull()
+	case bson.TypeString:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		p, err := rut.Parse(s)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(p))
+		return nil
+	}
+	return fmt.Errorf("rutbson: cannot decode %v into a rut", vr.Type())
+}
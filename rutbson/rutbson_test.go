@@ -0,0 +1,77 @@
+package rutbson
+
+import (
+	"bytes"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/alvarolm/rut"
+)
+
+type client struct {
+	RUT RUT    `bson:"rut"`
+	Ref RUT    `bson:"ref"`
+	Raw string `bson:"raw,omitempty"`
+}
+
+func TestRUT(t *testing.T) {
+	b, err := bson.Marshal(client{RUT: "13.117.182-k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := bson.Raw(b)
+	if v := raw.Lookup("rut"); v.Type != bson.TypeString || v.StringValue() != "13117182-K" {
+		t.Error("unexpected rut", v)
+	}
+	if v := raw.Lookup("ref"); v.Type != bson.TypeNull {
+		t.Error("expected null, got", v)
+	}
+
+	var c client
+	if err := bson.Unmarshal(b, &c); err != nil || c.RUT != "13117182-K" || c.Ref != "" {
+		t.Error("unexpected client", c, err)
+	}
+
+	if _, err := bson.Marshal(client{RUT: "12345678-0"}); err == nil {
+		t.Error("expected an error marshaling an invalid rut")
+	}
+	b, _ = bson.Marshal(bson.M{"rut": "12345678-0"})
+	if err := bson.Unmarshal(b, &c); err == nil {
+		t.Error("expected an error unmarshaling an invalid rut")
+	}
+	b, _ = bson.Marshal(bson.M{"rut": 12345678})
+	if err := bson.Unmarshal(b, &c); err == nil {
+		t.Error("expected an error unmarshaling a number")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	reg := bson.NewRegistry()
+	Register(reg)
+
+	type doc struct {
+		RUT rut.Rut `bson:"rut"`
+	}
+
+	var buf bytes.Buffer
+	enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+	enc.SetRegistry(reg)
+	if err := enc.Encode(doc{RUT: "12.345.678-5"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := bson.Raw(buf.Bytes()).Lookup("rut"); v.StringValue() != "12345678-5" {
+		t.Error("unexpected rut", v)
+	}
+	if err := enc.Encode(doc{RUT: "12345678-0"}); err == nil {
+		t.Error("expected an error encoding an invalid rut")
+	}
+
+	b, _ := bson.Marshal(bson.M{"rut": "12.345.678-5"})
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(b)))
+	dec.SetRegistry(reg)
+	var d doc
+	if err := dec.Decode(&d); err != nil || d.RUT != "12345678-5" {
+		t.Error("unexpected doc", d, err)
+	}
+}
@@ -0,0 +1,101 @@
+package rutcore
+
+// maxBuilderDigits keeps the 'cuerpo' of a DVBuilder within a uint64
+const maxBuilderDigits = 19
+
+// DVBuilder computes the 'digito verificador' of a 'cuerpo' keyed one digit
+// at a time, as in IVR and kiosk interfaces, in constant time per digit.
+// the zero value is an empty 'cuerpo'
+type DVBuilder struct {
+	// sums[j] is the weighted sum of the digits as if j more digits
+	// followed them, sums[0] is the one of the 'cuerpo' as is
+	sums [period]int
+	body uint64
+	n    int
+}
+
+// WriteDigit appends the digit c to the 'cuerpo', false for any other
+// character or past 19 digits. the zero padding is ignored
+func (b *DVBuilder) WriteDigit(c byte) bool {
+	d := c - '0'
+	if d > 9 || b.n == maxBuilderDigits {
+		return false
+	}
+	if b.n == 0 && d == 0 {
+		return true
+	}
+
+	// every previous digit moves one position left
+	first := b.sums[0]
+	copy(b.sums[:], b.sums[1:])
+	b.sums[len(b.sums)-1] = first
+	for j := range b.sums {
+		b.sums[j] += scheme.Contribution(j, d)
+	}
+	b.body = b.body*10 + uint64(d)
+	b.n++
+	return true
+}
+
+// Backspace removes the last digit of the 'cuerpo', false when it's empty
+func (b *DVBuilder) Backspace() bool {
+	if b.n == 0 {
+		return false
+	}
+	d := byte(b.body % 10)
+	for j := range b.sums {
+		b.sums[j] -= scheme.Contribution(j, d)
+	}
+	last := b.sums[len(b.sums)-1]
+	copy(b.sums[1:], b.sums[:len(b.sums)-1])
+	b.sums[0] = last
+	b.body /= 10
+	b.n--
+	return true
+}
+
+// Reset empties the 'cuerpo'
+func (b *DVBuilder) Reset() {
+	*b = DVBuilder{}
+}
+
+// Len returns the number of digits of the 'cuerpo', zero padding excluded
+func (b *DVBuilder) Len() int {
+	return b.n
+}
+
+// Body returns the 'cuerpo' keyed so far
+func (b *DVBuilder) Body() uint64 {
+	return b.body
+}
+
+// DV returns the 'digito verificador' of the 'cuerpo' keyed so far,
+// 0 while it's empty
+func (b *DVBuilder) DV() byte {
+	if b.n == 0 {
+		return 0
+	}
+	return symbol(b.sums[0])
+}
+
+// Check validates the keyed 'digito verificador' dv against the 'cuerpo'
+// the way Check does with the rut 'cuerpo-dv', a lowercase 'k' is accepted
+func (b *DVBuilder) Check(dv byte, minLen, maxLen int) Status {
+	n := b.n + 2
+	if n < minLen || n < 3 {
+		return ErrMinLength
+	} else if n > maxLen {
+		return ErrMaxLength
+	}
+	switch {
+	case dv >= '0' && dv <= '9', dv == 'K':
+	case dv == 'k':
+		dv = 'K'
+	default:
+		return ErrInvalidDVchar
+	}
+	if symbol(b.sums[0]) != dv {
+		return ErrInvalidDV
+	}
+	return OK
+}
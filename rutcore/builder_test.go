@@ -0,0 +1,74 @@
+package rutcore_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+func TestDVBuilder(t *testing.T) {
+	var b rutcore.DVBuilder
+	if b.DV() != 0 || b.Backspace() {
+		t.Fatal("expected an empty builder")
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		b.Reset()
+		body := strconv.Itoa(rnd.Intn(999999999))
+		for j := 0; j < len(body); j++ {
+			if !b.WriteDigit(body[j]) {
+				t.Fatal(body, "unexpected rejected digit")
+			}
+			expected, _ := rutcore.DVString(body[:j+1])
+			if b.DV() != expected {
+				t.Fatalf("%s: expected %c, got %c", body[:j+1], expected, b.DV())
+			}
+		}
+		for j := len(body) - 1; j > 0; j-- {
+			b.Backspace()
+			expected, _ := rutcore.DVString(body[:j])
+			if b.DV() != expected || b.Body() != parse(body[:j]) {
+				t.Fatalf("%s: expected %c, got %c %d", body[:j], expected, b.DV(), b.Body())
+			}
+		}
+	}
+}
+
+func TestDVBuilderCheck(t *testing.T) {
+	var b rutcore.DVBuilder
+	for _, c := range []byte("013117182") {
+		b.WriteDigit(c)
+	}
+	if b.Len() != 8 || b.Body() != 13117182 || b.WriteDigit('-') {
+		t.Fatal("unexpected builder", b.Len(), b.Body())
+	}
+
+	for dv, expected := range map[byte]rutcore.Status{
+		'K': rutcore.OK,
+		'k': rutcore.OK,
+		'1': rutcore.ErrInvalidDV,
+		'x': rutcore.ErrInvalidDVchar,
+	} {
+		if st := b.Check(dv, rutcore.MinLength, rutcore.MaxLength); st != expected {
+			t.Errorf("%c: expected %q, got %q", dv, expected, st)
+		}
+	}
+
+	b.WriteDigit('1')
+	if st := b.Check(b.DV(), rutcore.MinLength, rutcore.MaxLength); st != rutcore.ErrMaxLength {
+		t.Error("expected max_length, got", st)
+	}
+	b.Reset()
+	b.WriteDigit('1')
+	if st := b.Check('9', rutcore.MinLength, rutcore.MaxLength); st != rutcore.ErrMinLength {
+		t.Error("expected min_length, got", st)
+	}
+}
+
+func parse(s string) uint64 {
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v
+}
@@ -0,0 +1,160 @@
+/*
+Package rutcore is the 'digito verificador' arithmetic of package rut
+reduced to what validation needs: it sums the contribution tables of
+checkdigit.RUT, imports nothing else, doesn't allocate and doesn't depend
+on math/rand or time, so it builds small with TinyGo for firmware and
+other constrained devices.
+
+Check agrees with rut.Validate on every input.
+*/
+package rutcore
+
+import "github.com/alvarolm/rut/checkdigit"
+
+// Length bounds used by Valid, the defaults of rut.MinRutlength and rut.MaxRutlength
+const (
+	// NNNNNNN-N
+	MinLength = 9
+
+	// NNNNNNNN-N
+	MaxLength = 10
+)
+
+// Status is the outcome of Check, the values mirror the rut package errors
+type Status uint8
+
+const (
+	OK Status = iota
+	ErrMinLength
+	ErrMaxLength
+	ErrNoDVSeparator
+	ErrInvalidDVchar
+	ErrExpectedDigit
+	ErrInvalidDV
+)
+
+// String returns the code of the equivalent rut package error, "" for OK
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return ""
+	case ErrMinLength:
+		return "min_length"
+	case ErrMaxLength:
+		return "max_length"
+	case ErrNoDVSeparator:
+		return "no_dv_separator"
+	case ErrInvalidDVchar:
+		return "invalid_dv_char"
+	case ErrExpectedDigit:
+		return "expected_digit"
+	case ErrInvalidDV:
+		return "invalid_dv"
+	default:
+		return "unknown"
+	}
+}
+
+// DVSymbols are the 'digito verificador' symbols, the digits and 'K'
+const DVSymbols = "0123456789K"
+
+// scheme is the 'digito verificador' scheme, period its number of weights
+var scheme = checkdigit.RUT
+
+const period = 6
+
+// symbol returns the 'digito verificador' of a sum of scheme contributions,
+// every remainder has one
+func symbol(sum int) byte {
+	dv, _ := scheme.Symbol(sum)
+	return dv
+}
+
+// DV returns the 'digito verificador' of a numeric 'cuerpo'
+func DV(body uint64) byte {
+	var sum int
+	for p := 0; body > 0; body /= 10 {
+		sum += scheme.Contribution(p, byte(body%10))
+		if p++; p == period {
+			p = 0
+		}
+	}
+	return symbol(sum)
+}
+
+// DVString returns the 'digito verificador' of a 'cuerpo' of decimal digits,
+// ok is false when it's empty or has any other character
+func DVString(body string) (dv byte, ok bool) {
+	dv, err := scheme.Compute(body)
+	return dv, err == nil
+}
+
+// Check validates s the way rut.Validate does: decimal points are ignored,
+// the remaining length, 'cuerpo' zero padding included, must be within
+// [minLen, maxLen] and a lowercase 'k' is accepted.
+// body and dv are set once the format is valid, body overflows past 19 digits
+func Check(s string, minLen, maxLen int) (body uint64, dv byte, st Status) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '.' {
+			n++
+		}
+	}
+	// at least one 'cuerpo' digit whatever minLen is set to
+	if n < minLen || n < 3 {
+		st = ErrMinLength
+		return
+	} else if n > maxLen {
+		st = ErrMaxLength
+		return
+	}
+
+	i := len(s) - 1
+	for s[i] == '.' {
+		i--
+	}
+	dv = s[i]
+	for i--; s[i] == '.'; i-- {
+	}
+	if s[i] != '-' {
+		dv, st = 0, ErrNoDVSeparator
+		return
+	}
+	switch {
+	case dv >= '0' && dv <= '9', dv == 'K':
+	case dv == 'k':
+		dv = 'K'
+	default:
+		dv, st = 0, ErrInvalidDVchar
+		return
+	}
+
+	var sum int
+	p := 0
+	for pow, j := uint64(1), i-1; j >= 0; j-- {
+		if s[j] == '.' {
+			continue
+		}
+		d := s[j] - '0'
+		if d > 9 {
+			body, st = 0, ErrExpectedDigit
+			return
+		}
+		body += uint64(d) * pow
+		pow *= 10
+		sum += scheme.Contribution(p, d)
+		if p++; p == period {
+			p = 0
+		}
+	}
+	if symbol(sum) != dv {
+		st = ErrInvalidDV
+	}
+	return
+}
+
+// Valid reports whether s is a valid rut within MinLength and MaxLength
+func Valid(s string) bool {
+	_, _, st := Check(s, MinLength, MaxLength)
+	return st == OK
+}
@@ -0,0 +1,85 @@
+package rutcore_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutcore"
+)
+
+var inputs = []string{
+	"", "-", "1-", "K", "11111111-1", "11.111.111-1", "12345678-5",
+	"12.345.678-5", "12345678-k", "12345678-K", "5126663-3", "123456785",
+	"1234567-", "12345678--5", "12.345.678–5", "12345678-５", "ñ1234567-5",
+	"+1234567-4", "01234567-4", "0000000-0", "00.000.000-0", "000000000-0",
+	"012345678-5", "0000000000000012345678-5", "99999999-9",
+	"100000000-2", "........", "........-1", "1.2.3.4.5.6.7.8-5", "12345678.-5",
+	"12345678-.5", "12345678-5.", "12345678-0",
+}
+
+// check is the rutcore equivalent of rut.Validate
+func check(t *testing.T, input string) {
+	t.Helper()
+	r := rut.Rut(input)
+	_, err := r.Validate()
+	body, dv, st := rutcore.Check(input, rut.MinRutlength, rut.MaxRutlength)
+	if st.String() != rut.Code(err) {
+		t.Fatalf("%q: expected %q, got %q", input, rut.Code(err), st)
+	}
+	if err == nil && strconv.FormatUint(body, 10)+"-"+string(dv) != r.Key() {
+		t.Fatalf("%q: expected %s, got %d-%c", input, r, body, dv)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, input := range inputs {
+		check(t, input)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		body := rnd.Intn(100000000)
+		check(t, strconv.Itoa(body)+"-"+string("0123456789Kk"[rnd.Intn(12)]))
+	}
+}
+
+func TestDV(t *testing.T) {
+	for body := uint64(0); body < 100000; body++ {
+		dv, ok := rutcore.DVString(strconv.FormatUint(body, 10))
+		if !ok || dv != rutcore.DV(body) {
+			t.Fatal(body, "expected", string(rutcore.DV(body)), "got", string(dv), ok)
+		}
+	}
+	if _, ok := rutcore.DVString(""); ok {
+		t.Error("expected not ok for an empty 'cuerpo'")
+	}
+	if _, ok := rutcore.DVString("12a"); ok {
+		t.Error("expected not ok for a non digit")
+	}
+}
+
+func TestValid(t *testing.T) {
+	if !rutcore.Valid("12.345.678-5") || rutcore.Valid("12.345.678-0") {
+		t.Error("unexpected validity")
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		rutcore.Check("12.345.678-k", rutcore.MinLength, rutcore.MaxLength)
+		rutcore.DVString("12345678")
+		rutcore.DV(12345678)
+		_ = rutcore.ErrInvalidDV.String()
+	})
+	if allocs != 0 {
+		t.Error("expected no allocations, got", allocs)
+	}
+}
+
+func BenchmarkCheck(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		rutcore.Check("12.345.678-5", rutcore.MinLength, rutcore.MaxLength)
+	}
+}
@@ -0,0 +1,9 @@
+package rutcore
+
+import "testing"
+
+func TestPeriod(t *testing.T) {
+	if period != scheme.Period() {
+		t.Error("expected", scheme.Period(), "got", period)
+	}
+}
@@ -0,0 +1,143 @@
+package rutcsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"slices"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrNoRutColumn   = rut.NewError("no_rut_column", "no column holds valid ruts")
+	ErrLowConfidence = rut.NewError("low_confidence", "rut column detected with low confidence")
+)
+
+// DefaultMinConfidence is the Processor.MinConfidence used when unset
+const DefaultMinConfidence = 0.5
+
+// ColumnScore is how likely a column holds the ruts
+type ColumnScore struct {
+	// Column is the zero based index of the column
+	Column int
+
+	// Name is the column header, set when the records have one
+	Name string
+
+	// Valid counts the sampled records holding a valid rut in the column
+	Valid int
+
+	// Score is Valid over the sampled records
+	Score float64
+}
+
+// Detection is the outcome of Processor.Detect
+type Detection struct {
+	// Column is the zero based index of the best scoring column
+	Column int
+
+	// Confidence is the Score of Column minus the Score of the runner
+	// up, 1 when every sampled record holds a valid rut in Column and no
+	// other column has any. files with two rut columns score low
+	Confidence float64
+
+	// Rows counts the sampled records, the header excluded
+	Rows int
+
+	// Columns holds the score of every column, best first
+	Columns []ColumnScore
+}
+
+// Detect samples up to rows records of r and scores every column by the
+// rate of valid ruts it holds, the first record is read as the header
+// when Header or HasHeader is set. ErrNoRutColumn is returned when no
+// column has a valid rut
+func (p *Processor) Detect(r io.Reader, rows int) (d Detection, err error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	if p.Comma != 0 {
+		cr.Comma = p.Comma
+	}
+
+	var names []string
+	if p.Header != "" || p.HasHeader {
+		if names, err = cr.Read(); err != nil {
+			if err == io.EOF {
+				err = ErrNoRutColumn
+			}
+			return
+		}
+		names = slices.Clone(names)
+	}
+
+	var valid []int
+	for d.Rows < rows {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return d, err
+		}
+		d.Rows++
+		for len(valid) < len(record) {
+			valid = append(valid, 0)
+		}
+		for i := range record {
+			// the same value Process validates
+			if validate(record, i).Err == nil {
+				valid[i]++
+			}
+		}
+	}
+
+	d.Columns = make([]ColumnScore, max(len(valid), len(names)))
+	for i := range d.Columns {
+		c := &d.Columns[i]
+		c.Column = i
+		if i < len(names) {
+			c.Name = names[i]
+		}
+		if i < len(valid) {
+			c.Valid = valid[i]
+			c.Score = float64(c.Valid) / float64(d.Rows)
+		}
+	}
+	slices.SortStableFunc(d.Columns, func(a, b ColumnScore) int {
+		return b.Valid - a.Valid
+	})
+
+	if len(d.Columns) == 0 || d.Columns[0].Valid == 0 {
+		return d, ErrNoRutColumn
+	}
+	d.Column, d.Confidence = d.Columns[0].Column, d.Columns[0].Score
+	if len(d.Columns) > 1 {
+		d.Confidence -= d.Columns[1].Score
+	}
+	return d, nil
+}
+
+// detect selects the column of the records of r by DetectRows and returns
+// r rewound to its start
+func (p *Processor) detect(r io.Reader) (column int, rewound io.Reader, err error) {
+	var sample bytes.Buffer
+	d, err := p.Detect(io.TeeReader(r, &sample), p.DetectRows)
+	rewound = io.MultiReader(&sample, r)
+	if err != nil {
+		return
+	}
+	if p.OnDetect != nil {
+		p.OnDetect(d)
+	}
+
+	threshold := p.MinConfidence
+	if threshold == 0 {
+		threshold = DefaultMinConfidence
+	}
+	if d.Confidence < threshold {
+		return d.Column, rewound, ErrLowConfidence
+	}
+	return d.Column, rewound, nil
+}
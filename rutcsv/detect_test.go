@@ -0,0 +1,82 @@
+package rutcsv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestDetect(t *testing.T) {
+	in := strings.Join([]string{
+		"id;cliente;telefono",
+		"1;11.111.111-1;56912345678",
+		"2;12.345.678-5;56987654321",
+		"3;13117182-k;",
+		"4;12345678-0;56911111111",
+		"5;;",
+	}, "\n")
+
+	p := Processor{HasHeader: true, Comma: ';'}
+	d, err := p.Detect(strings.NewReader(in), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Column != 1 || d.Rows != 5 || d.Confidence != 0.6 {
+		t.Errorf("unexpected detection %+v", d)
+	}
+	if best := d.Columns[0]; best.Column != 1 || best.Name != "cliente" || best.Valid != 3 {
+		t.Errorf("unexpected best column %+v", best)
+	}
+	if len(d.Columns) != 3 {
+		t.Error("expected 3 columns, got", len(d.Columns))
+	}
+
+	d, err = p.Detect(strings.NewReader(in), 2)
+	if err != nil || d.Rows != 2 || d.Confidence != 1 {
+		t.Errorf("unexpected detection %+v %v", d, err)
+	}
+
+	if _, err := p.Detect(strings.NewReader("a;b\nx;y\n"), 10); err != ErrNoRutColumn || rut.Code(err) != "no_rut_column" {
+		t.Error("expected ErrNoRutColumn, got", err)
+	}
+
+	// padded ruts fail in Process, so they don't score
+	if _, err := p.Detect(strings.NewReader("a;b\nx; 11.111.111-1\ny; 13117182-k\n"), 10); err != ErrNoRutColumn {
+		t.Error("expected ErrNoRutColumn, got", err)
+	}
+}
+
+func TestProcessDetect(t *testing.T) {
+	in := "uno,11.111.111-1\ndos,12345678-0\ntres,13117182-k\n"
+
+	var (
+		out      bytes.Buffer
+		detected Detection
+	)
+	p := Processor{DetectRows: 2, OnDetect: func(d Detection) { detected = d }}
+	report, err := p.Process(&out, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detected.Column != 1 || detected.Rows != 2 {
+		t.Errorf("unexpected detection %+v", detected)
+	}
+	if report.Total != 3 || report.Valid != 2 {
+		t.Error("unexpected report", report)
+	}
+	if !strings.HasPrefix(out.String(), "uno,11.111.111-1,true,1,11111111-1,\n") {
+		t.Error("unexpected output", out.String())
+	}
+
+	// two rut columns are ambiguous
+	p = Processor{DetectRows: 10}
+	if _, err := p.Process(&out, strings.NewReader("11111111-1,12345678-5\n")); err != ErrLowConfidence || rut.Code(err) != "low_confidence" {
+		t.Error("expected ErrLowConfidence, got", err)
+	}
+	p.MinConfidence = -1
+	if report, err := p.Process(&out, strings.NewReader("11111111-1,12345678-5\n")); err != nil || report.Valid != 1 {
+		t.Error("unexpected result", report, err)
+	}
+}
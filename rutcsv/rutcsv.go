@@ -0,0 +1,199 @@
+/*
+Package rutcsv validates and normalizes a 'Rol Único Tributario' column
+of csv streams, one record at a time
+*/
+package rutcsv
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrHeaderNotFound = rut.NewError("header_not_found", "rut column header not found")
+	ErrNoColumn       = rut.NewError("no_column", "record has no rut column")
+)
+
+// annotation columns appended to every record
+var annotations = []string{"rut_valid", "rut_expected_dv", "rut_normalized", "rut_error"}
+
+// Processor annotates csv records with the validation of one of their columns
+type Processor struct {
+	// Column is the zero based index of the rut column,
+	// ignored when Header is set
+	Column int
+
+	// Header selects the rut column by name,
+	// the first record is read as the header
+	Header string
+
+	// HasHeader marks the first record as a header when selecting by Column
+	HasHeader bool
+
+	// Comma is the field delimiter, defaults to ','
+	Comma rune
+
+	// Order sorts the records Process writes by their rut, the invalid
+	// ones last. the default rut.OrderInput keeps the input order
+	Order rut.Order
+
+	// DetectRows, if positive and Header is unset, selects the rut column
+	// by sampling the first DetectRows records with Detect instead of
+	// using Column. the sampled records are processed as usual
+	DetectRows int
+
+	// MinConfidence fails the detection below the given Confidence with
+	// ErrLowConfidence, defaults to DefaultMinConfidence, a negative one
+	// accepts any
+	MinConfidence float64
+
+	// OnDetect, if set, receives the Detection of DetectRows before the
+	// records are processed, eg. to report the selected column
+	OnDetect func(Detection)
+}
+
+// Process reads the csv records of r and writes them to w followed by
+// the rut_valid, rut_expected_dv, rut_normalized and rut_error columns.
+// records are streamed, the input is never held in memory unless they
+// are sorted by Order, the report accounts every record but the header
+func (p *Processor) Process(w io.Writer, r io.Reader) (report rut.Report, err error) {
+	cw := csv.NewWriter(w)
+	if p.Comma != 0 {
+		cw.Comma = p.Comma
+	}
+
+	var sorted []annotated
+	report, err = p.scan(r,
+		func(header []string) error {
+			return cw.Write(append(header, annotations...))
+		},
+		func(record []string, res rut.Result) error {
+			if p.Order != rut.OrderInput {
+				sorted = append(sorted, annotated{slices.Clone(record), res})
+				return nil
+			}
+			return cw.Write(append(record, annotate(res)...))
+		},
+	)
+	if err != nil {
+		return
+	}
+
+	slices.SortStableFunc(sorted, func(a, b annotated) int {
+		return p.Order.Compare(a.res, b.res)
+	})
+	for _, rec := range sorted {
+		if err = cw.Write(append(rec.record, annotate(rec.res)...)); err != nil {
+			return
+		}
+	}
+
+	cw.Flush()
+	return report, cw.Error()
+}
+
+// annotated is a record held to be sorted
+type annotated struct {
+	record []string
+	res    rut.Result
+}
+
+// Each streams the validation of every record of r to fn, with the record
+// number counted from 1 after the header, until fn returns an error
+func (p *Processor) Each(r io.Reader, fn func(record int, res rut.Result) error) (rut.Report, error) {
+	var n int
+	return p.scan(r, nil, func(record []string, res rut.Result) error {
+		n++
+		return fn(n, res)
+	})
+}
+
+// scan reads the header, if any, and validates every record.
+// records are reused between calls
+func (p *Processor) scan(r io.Reader, header func([]string) error, fn func([]string, rut.Result) error) (report rut.Report, err error) {
+	column := p.Column
+	if p.DetectRows > 0 && p.Header == "" {
+		if column, r, err = p.detect(r); err != nil {
+			return
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	if p.Comma != 0 {
+		cr.Comma = p.Comma
+	}
+
+	if p.Header != "" || p.HasHeader {
+		var names []string
+		if names, err = cr.Read(); err != nil {
+			if err == io.EOF {
+				err = nil
+				if p.Header != "" {
+					err = ErrHeaderNotFound
+				}
+			}
+			return
+		}
+
+		if p.Header != "" {
+			if column = indexOf(names, p.Header); column < 0 {
+				return report, ErrHeaderNotFound
+			}
+		}
+
+		if header != nil {
+			if err = header(names); err != nil {
+				return
+			}
+		}
+	}
+
+	for {
+		var record []string
+		if record, err = cr.Read(); err != nil {
+			if err == io.EOF {
+				return report, nil
+			}
+			return
+		}
+
+		res := validate(record, column)
+		report.Add(res)
+		if err = fn(record, res); err != nil {
+			return
+		}
+	}
+}
+
+func validate(record []string, column int) rut.Result {
+	if column < 0 || column >= len(record) {
+		return rut.Result{Err: ErrNoColumn}
+	}
+	return rut.Check(record[column])
+}
+
+// annotate returns the annotation fields of a result
+func annotate(res rut.Result) []string {
+	var expected string
+	if res.ExpectedDV != 0 {
+		expected = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		return []string{"false", expected, "", res.Err.Error()}
+	}
+	return []string{"true", expected, string(res.Rut), ""}
+}
+
+func indexOf(header []string, name string) int {
+	for i, h := range header {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
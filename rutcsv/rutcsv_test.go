@@ -0,0 +1,93 @@
+package rutcsv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestProcessHeader(t *testing.T) {
+	in := strings.Join([]string{
+		"name,rut",
+		"uno,11.111.111-1",
+		"dos,12345678-0",
+		"tres,13117182-k",
+		"cuatro",
+	}, "\n")
+
+	var out bytes.Buffer
+	p := Processor{Header: "rut"}
+	report, err := p.Process(&out, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Valid != 2 || report.Errors["no_column"] != 1 {
+		t.Error("unexpected report", report)
+	}
+
+	expected := strings.Join([]string{
+		"name,rut,rut_valid,rut_expected_dv,rut_normalized,rut_error",
+		"uno,11.111.111-1,true,1,11111111-1,",
+		"dos,12345678-0,false,5,,invalid 'digito verificador'",
+		"tres,13117182-k,true,K,13117182-K,",
+		"cuatro,false,,,record has no rut column",
+		"",
+	}, "\n")
+	if out.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestProcessColumn(t *testing.T) {
+	var out bytes.Buffer
+	p := Processor{Column: 1, Comma: ';'}
+	if _, err := p.Process(&out, strings.NewReader("x;11111111-1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "x;11111111-1;true;1;11111111-1;\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestProcessOrder(t *testing.T) {
+	in := "a,12345678-0\nb,12.345.678-5\nc,9.999.999-3\n"
+
+	var out bytes.Buffer
+	p := Processor{Column: 1, Order: rut.OrderNumeric}
+	if _, err := p.Process(&out, strings.NewReader(in)); err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		"c,9.999.999-3,true,3,9999999-3,",
+		"b,12.345.678-5,true,5,12345678-5,",
+		"a,12345678-0,false,5,,invalid 'digito verificador'",
+		"",
+	}, "\n")
+	if out.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestProcessHeaderNotFound(t *testing.T) {
+	p := Processor{Header: "rut"}
+	if _, err := p.Process(&bytes.Buffer{}, strings.NewReader("a,b\n1,2\n")); err != ErrHeaderNotFound || rut.Code(err) != "header_not_found" {
+		t.Error("expected", ErrHeaderNotFound, "got", err)
+	}
+}
+
+func TestEach(t *testing.T) {
+	var records []int
+	p := Processor{HasHeader: true}
+	report, err := p.Each(strings.NewReader("rut\n11111111-1\n12345678-0\n"), func(record int, res rut.Result) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1] != 2 || report.Invalid != 1 {
+		t.Error("unexpected", records, report)
+	}
+}
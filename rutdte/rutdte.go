@@ -0,0 +1,86 @@
+/*
+Package rutdte renders and parses the 'Rol Único Tributario' fields of the
+SII electronic documents (DTE), eg. RUTEmisor, RUTRecep and the RE and RR
+of the TED barcode, all of them in the 'NNNNNNNN-D' form: no dots, no
+'cuerpo' zero padding and an uppercase 'K'
+*/
+package rutdte
+
+import (
+	"errors"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrNotCanonical = errors.New("rut field not in the 'NNNNNNNN-D' form")
+	ErrBodyTooLong  = errors.New("rut field 'cuerpo' exceeds 8 digits")
+)
+
+// Ruts of the receptors without one
+const (
+	// ConsumidorFinal is the RUTRecep of the boletas to anonymous buyers
+	ConsumidorFinal rut.Rut = "66666666-6"
+
+	// Extranjero is the RUTRecep of the export documents
+	Extranjero rut.Rut = "55555555-5"
+)
+
+// maxBody is the largest 'cuerpo' of the schema RUTType, [0-9]{1,8}-[0-9K]
+const maxBody = 99999999
+
+// Format validates r and returns its DTE form, dotted and lowercase 'k'
+// inputs are accepted
+func Format(r rut.Rut) (string, error) {
+	body, err := r.Body()
+	if err != nil {
+		return "", err
+	}
+	if body > maxBody {
+		return "", ErrBodyTooLong
+	}
+	return r.Key(), nil
+}
+
+// Parse validates a DTE field, unlike rut.Parse it rejects the values not
+// already in the DTE form
+func Parse(field string) (rut.Rut, error) {
+	f, err := Format(rut.Rut(field))
+	if err != nil {
+		return "", err
+	}
+	if f != field {
+		return "", ErrNotCanonical
+	}
+	return rut.Rut(f), nil
+}
+
+// RUT is a DTE rut field, use it as the type of the RUTEmisor, RUTRecep,
+// RE and RR fields of the encoding/xml structs
+//
+//	type DD struct {
+//		RE rutdte.RUT `xml:"RE"`
+//		TD int       `xml:"TD"`
+//		F  int       `xml:"F"`
+//		RR rutdte.RUT `xml:"RR"`
+//	}
+type RUT rut.Rut
+
+// MarshalText implements encoding.TextMarshaler with Format
+func (r RUT) MarshalText() ([]byte, error) {
+	f, err := Format(rut.Rut(r))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(f), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with Parse
+func (r *RUT) UnmarshalText(text []byte) error {
+	p, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*r = RUT(p)
+	return nil
+}
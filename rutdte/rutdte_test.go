@@ -0,0 +1,71 @@
+package rutdte
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestFormat(t *testing.T) {
+	for in, expected := range map[rut.Rut]string{
+		"12.345.678-5":  "12345678-5",
+		"13.117.182-k":  "13117182-K",
+		"01.234.567-4":  "1234567-4",
+		ConsumidorFinal: "66666666-6",
+		Extranjero:      "55555555-5",
+	} {
+		if f, err := Format(in); err != nil || f != expected {
+			t.Error(in, "expected", expected, "got", f, err)
+		}
+	}
+
+	if _, err := Format("12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if r, err := Parse("13117182-K"); err != nil || r != "13117182-K" {
+		t.Error("unexpected", r, err)
+	}
+	for _, field := range []string{"13117182-k", "13.117.182-K", "01234567-4"} {
+		if _, err := Parse(field); err != ErrNotCanonical {
+			t.Error(field, "expected ErrNotCanonical, got", err)
+		}
+	}
+}
+
+type dd struct {
+	XMLName xml.Name `xml:"DD"`
+	RE      RUT      `xml:"RE"`
+	TD      int      `xml:"TD"`
+	F       int      `xml:"F"`
+	RR      RUT      `xml:"RR"`
+}
+
+func TestXML(t *testing.T) {
+	out, err := xml.Marshal(dd{RE: "76.086.428-5", TD: 39, F: 1, RR: RUT(ConsumidorFinal)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "<DD><RE>76086428-5</RE><TD>39</TD><F>1</F><RR>66666666-6</RR></DD>"
+	if string(out) != expected {
+		t.Fatal("expected", expected, "got", string(out))
+	}
+
+	var d dd
+	if err := xml.Unmarshal(out, &d); err != nil || d.RE != "76086428-5" || d.RR != "66666666-6" {
+		t.Error("unexpected", d, err)
+	}
+
+	in := strings.Replace(expected, "76086428-5", "76.086.428-5", 1)
+	if err := xml.Unmarshal([]byte(in), &d); err != ErrNotCanonical {
+		t.Error("expected ErrNotCanonical, got", err)
+	}
+
+	if _, err := xml.Marshal(dd{RE: "76086428-0"}); err == nil {
+		t.Error("expected an error marshaling an invalid rut")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Package rutecho validates the rut fields of the Echo bound structs
+
+	e.Validator = rutecho.NewValidator()
+
+	e.POST("/clients", func(c echo.Context) error {
+		var req struct {
+			RUT rut.Rut `json:"rut" validate:"rut"`
+		}
+		if err := rutecho.Bind(c, &req); err != nil {
+			return err
+		}
+		...
+	})
+
+invalid ruts are answered with 422 and the other binding failures with
+400, both with a ruthttp.ErrorResponse body. rut.Rut fields are decoded
+normalized, eg. "12.345.678-5" binds as "12345678-5"
+*/
+package rutecho
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/ruthttp"
+	"github.com/alvarolm/rut/rutvalidator"
+)
+
+// Validator is an echo.Validator with the rutvalidator.Tag
+type Validator struct {
+	v *validator.Validate
+}
+
+// NewValidator returns a Validator
+func NewValidator() *Validator {
+	v := validator.New()
+	// registering a non empty tag never fails
+	rutvalidator.Register(v)
+	return &Validator{v}
+}
+
+// Validate returns an *echo.HTTPError with a ruthttp.ErrorResponse message
+// when i isn't valid
+func (v *Validator) Validate(i any) error {
+	err := v.v.Struct(i)
+	if err == nil {
+		return nil
+	}
+	if rerr := rutvalidator.Error(err); rerr != nil {
+		return httpError(http.StatusUnprocessableEntity, rerr)
+	}
+	return httpError(http.StatusBadRequest, rut.NewError(ruthttp.CodeBadRequest, err.Error()))
+}
+
+// Bind binds the request to obj and validates it with the echo Validator,
+// the errors are *echo.HTTPError with a ruthttp.ErrorResponse message
+func Bind(c echo.Context, obj any) error {
+	if err := c.Bind(obj); err != nil {
+		// rut.Rut fields fail while decoding
+		var rerr *rut.Error
+		if errors.As(err, &rerr) {
+			return httpError(http.StatusUnprocessableEntity, rerr)
+		}
+		return httpError(http.StatusBadRequest, rut.NewError(ruthttp.CodeBadRequest, err.Error()))
+	}
+	return c.Validate(obj)
+}
+
+func httpError(status int, err error) error {
+	return echo.NewHTTPError(status, ruthttp.NewErrorResponse(err)).SetInternal(err)
+}
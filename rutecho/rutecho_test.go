@@ -0,0 +1,65 @@
+package rutecho
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/ruthttp"
+)
+
+type request struct {
+	RUT rut.Rut `json:"rut" validate:"rut"`
+	Ref string  `json:"ref" validate:"omitempty,rut"`
+}
+
+func serve(t *testing.T, body string) (*httptest.ResponseRecorder, ruthttp.ErrorResponse) {
+	e := echo.New()
+	e.Validator = NewValidator()
+	e.POST("/", func(c echo.Context) error {
+		var req request
+		if err := Bind(c, &req); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, string(req.RUT))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	e.ServeHTTP(w, req)
+
+	var resp ruthttp.ErrorResponse
+	if w.Code != http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err, w.Body.String())
+		}
+	}
+	return w, resp
+}
+
+func TestBind(t *testing.T) {
+	if w, _ := serve(t, `{"rut":"12.345.678-5"}`); w.Code != http.StatusOK || w.Body.String() != "12345678-5" {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp := serve(t, `{"rut":"12.345.678-0"}`)
+	if w.Code != http.StatusUnprocessableEntity || resp.Error.Code != "invalid_dv" {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp = serve(t, `{"rut":"12.345.678-5","ref":"1-9"}`)
+	if w.Code != http.StatusUnprocessableEntity || resp.Error.Code != "min_length" || !strings.Contains(resp.Error.Message, "Ref") {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp = serve(t, `{"rut":`)
+	if w.Code != http.StatusBadRequest || resp.Error.Code != ruthttp.CodeBadRequest {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,78 @@
+package rutest
+
+import "github.com/alvarolm/rut"
+
+// Case is a tricky input and its outcome with the default settings of
+// rut.Check, either Normalized or Err is set
+type Case struct {
+	Name       string
+	Input      string
+	Normalized rut.Rut
+	Err        error
+}
+
+// Valid reports whether the input is valid
+func (c Case) Valid() bool {
+	return c.Err == nil
+}
+
+// Corpus holds the inputs the wrappers of rut should agree with it on,
+// range over it in table tests
+//
+//	for _, c := range rutest.Corpus {
+//		t.Run(c.Name, func(t *testing.T) { ... })
+//	}
+//
+// it mustn't be modified
+var Corpus = []Case{
+	// lengths
+	{Name: "empty", Input: "", Err: rut.ErrMinLength},
+	{Name: "single digit", Input: "1-9", Err: rut.ErrMinLength},
+	{Name: "six digit body", Input: "999999-3", Err: rut.ErrMinLength},
+	{Name: "zero body", Input: "0-0", Err: rut.ErrMinLength},
+	{Name: "zero padded zero body", Input: "0000000-0", Normalized: "0000000-0"},
+	{Name: "zero padding past the max length", Input: "000000000-0", Err: rut.ErrMaxLength},
+	{Name: "min body", Input: "1000000-9", Normalized: "1000000-9"},
+	{Name: "max body", Input: "99999999-9", Normalized: "99999999-9"},
+	{Name: "max body dotted", Input: "99.999.999-9", Normalized: "99999999-9"},
+	{Name: "nine digit body", Input: "100000000-7", Err: rut.ErrMaxLength},
+
+	// "k" vs "K"
+	{Name: "uppercase K", Input: "13117182-K", Normalized: "13117182-K"},
+	{Name: "lowercase k", Input: "13117182-k", Normalized: "13117182-K"},
+	{Name: "lowercase k dotted", Input: "13.117.182-k", Normalized: "13117182-K"},
+	{Name: "K for a digit", Input: "12345678-K", Err: rut.ErrinvalidDV},
+	{Name: "digit for a K", Input: "13117182-0", Err: rut.ErrinvalidDV},
+
+	// spellings
+	{Name: "undotted", Input: "12345678-5", Normalized: "12345678-5"},
+	{Name: "dotted", Input: "12.345.678-5", Normalized: "12345678-5"},
+	{Name: "partially dotted", Input: "12345.678-5", Normalized: "12345678-5"},
+	{Name: "repeated dots", Input: "12..345.678-5", Normalized: "12345678-5"},
+	{Name: "leading zero", Input: "01234567-4", Normalized: "01234567-4"},
+	{Name: "leading zero dotted", Input: "01.234.567-4", Normalized: "01234567-4"},
+	{Name: "leading zero past the max length", Input: "012345678-5", Err: rut.ErrMaxLength},
+	{Name: "commas", Input: "12,345,678-5", Err: rut.ErrMaxLength},
+	{Name: "spaces", Input: "12 345 678-5", Err: rut.ErrMaxLength},
+	{Name: "leading space", Input: " 12345678-5", Err: rut.ErrMaxLength},
+	{Name: "trailing newline", Input: "12345678-5\n", Err: rut.ErrMaxLength},
+	{Name: "leading space short", Input: " 1234567-4", Err: rut.ErrExpectedDigit},
+	{Name: "sign", Input: "+1234567-4", Err: rut.ErrExpectedDigit},
+
+	// separators
+	{Name: "no separator", Input: "123456785", Err: rut.ErrNoDVSeparator},
+	{Name: "no separator dotted", Input: "12.345.6785", Err: rut.ErrNoDVSeparator},
+	{Name: "missing dv", Input: "12345678-", Err: rut.ErrNoDVSeparator},
+	{Name: "en dash", Input: "12345678–5", Err: rut.ErrMaxLength},
+	{Name: "em dash", Input: "12345678—5", Err: rut.ErrMaxLength},
+	{Name: "minus sign", Input: "12345678−5", Err: rut.ErrMaxLength},
+	{Name: "en dash short", Input: "123456–0", Err: rut.ErrNoDVSeparator},
+	{Name: "hyphen short", Input: "123456‐0", Err: rut.ErrNoDVSeparator},
+
+	// characters
+	{Name: "invalid dv char", Input: "12345678-x", Err: rut.ErrInvalidDVchar},
+	{Name: "letter in body", Input: "1234567a-5", Err: rut.ErrExpectedDigit},
+	{Name: "fullwidth digits", Input: "１２３-4", Err: rut.ErrMaxLength},
+	{Name: "wrong dv", Input: "12345678-0", Err: rut.ErrinvalidDV},
+	{Name: "wrong dv dotted", Input: "12.345.678-0", Err: rut.ErrinvalidDV},
+}
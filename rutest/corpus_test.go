@@ -0,0 +1,25 @@
+package rutest
+
+import (
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestCorpus(t *testing.T) {
+	names := make(map[string]bool)
+	for _, c := range Corpus {
+		if names[c.Name] {
+			t.Error("duplicated case", c.Name)
+		}
+		names[c.Name] = true
+
+		res := rut.Check(c.Input)
+		if res.Err != c.Err || res.Rut != c.Normalized {
+			t.Errorf("%s: %q: got %q %v, expected %q %v", c.Name, c.Input, res.Rut, res.Err, c.Normalized, c.Err)
+		}
+		if c.Valid() != res.Valid() {
+			t.Error(c.Name, "unexpected Valid")
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Package rutest builds rut fixtures for the tests of the packages using rut
+
+	func TestSignup(t *testing.T) {
+		owner := rutest.RequireValid(t, "76.086.428-5")
+		client := rutest.RandomValid(t)
+		typo := rutest.RandomInvalid(t, rut.ErrinvalidDV)
+		...
+	}
+*/
+package rutest
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutcore"
+)
+
+// RequireValid returns the normalized form of s, failing the test when it
+// isn't valid
+func RequireValid(t testing.TB, s string) rut.Rut {
+	t.Helper()
+	r, err := rut.Parse(s)
+	if err != nil {
+		t.Fatalf("rut %q: %s (%s)", s, err, rut.Code(err))
+	}
+	return r
+}
+
+// RandomValid returns a random valid rut of a person or a company
+func RandomValid(t testing.TB) rut.Rut {
+	t.Helper()
+	body := rand.Intn(rut.DefaultPersonRange[1]-rut.DefaultPersonRange[0]) + rut.DefaultPersonRange[0]
+	if rand.Intn(4) == 0 {
+		body = rand.Intn(rut.DefaultCompanyRange[1]-rut.DefaultCompanyRange[0]) + rut.DefaultCompanyRange[0]
+	}
+	r, err := rut.FromBody(body)
+	if err != nil {
+		t.Fatal("rutest:", err)
+	}
+	return r
+}
+
+// RandomInvalid returns a random input failing validation with kind, one
+// of rut.ErrinvalidDV, rut.ErrMinLength, rut.ErrMaxLength,
+// rut.ErrNoDVSeparator, rut.ErrInvalidDVchar and rut.ErrExpectedDigit
+func RandomInvalid(t testing.TB, kind error) string {
+	t.Helper()
+	valid := string(RandomValid(t))
+	body, dv := valid[:len(valid)-2], valid[len(valid)-1]
+
+	var s string
+	switch kind {
+	case rut.ErrinvalidDV:
+		wrong := rutcore.DVSymbols[rand.Intn(len(rutcore.DVSymbols))]
+		for wrong == dv {
+			wrong = rutcore.DVSymbols[rand.Intn(len(rutcore.DVSymbols))]
+		}
+		s = body + "-" + string(wrong)
+	case rut.ErrMinLength:
+		// a 'cuerpo' of up to MinRutlength-3 digits, none when
+		// MinRutlength <= 3, '-5' is too short whatever it's set to
+		s = randomDigits(rand.Intn(max(rut.MinRutlength, 3)-2)) + "-" + string(dv)
+	case rut.ErrMaxLength:
+		s = randomDigits(rut.MaxRutlength-1) + "-" + string(dv)
+	case rut.ErrNoDVSeparator:
+		s = body + randomDigits(1) + string(dv)
+	case rut.ErrInvalidDVchar:
+		s = body + "-" + string("XYZ"[rand.Intn(3)])
+	case rut.ErrExpectedDigit:
+		i := rand.Intn(len(body))
+		s = body[:i] + "x" + body[i+1:] + "-" + string(dv)
+	default:
+		t.Fatalf("rutest: no random inputs failing with %v", kind)
+	}
+
+	if err := rut.Check(s).Err; err != kind {
+		t.Fatalf("rutest: %q failed with %v instead of %v", s, err, kind)
+	}
+	return s
+}
+
+// randomDigits returns n random digits, the first one isn't 0, "" when n <= 0
+func randomDigits(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte(byte('1' + rand.Intn(9)))
+	for i := 1; i < n; i++ {
+		sb.WriteByte(byte('0' + rand.Intn(10)))
+	}
+	return sb.String()
+}
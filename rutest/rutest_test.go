@@ -0,0 +1,62 @@
+package rutest
+
+import (
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestRequireValid(t *testing.T) {
+	if r := RequireValid(t, "13.117.182-k"); r != "13117182-K" {
+		t.Error("unexpected rut", r)
+	}
+
+	ft := &fakeT{TB: t}
+	func() {
+		defer func() { recover() }()
+		RequireValid(ft, "12345678-0")
+	}()
+	if !ft.failed {
+		t.Error("expected the test to fail")
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		r := RandomValid(t)
+		if _, err := r.Validate(); err != nil {
+			t.Fatal(r, err)
+		}
+
+		for _, kind := range []error{rut.ErrinvalidDV, rut.ErrMinLength, rut.ErrMaxLength, rut.ErrNoDVSeparator, rut.ErrInvalidDVchar, rut.ErrExpectedDigit} {
+			if s := RandomInvalid(t, kind); rut.Check(s).Err != kind {
+				t.Fatal(s, "expected", kind)
+			}
+		}
+	}
+}
+
+func TestRandomMinLength(t *testing.T) {
+	defer func(min int) { rut.MinRutlength = min }(rut.MinRutlength)
+	for _, min := range []int{1, 3, 4} {
+		rut.MinRutlength = min
+		for i := 0; i < 100; i++ {
+			if s := RandomInvalid(t, rut.ErrMinLength); rut.Check(s).Err != rut.ErrMinLength {
+				t.Fatal(s, "expected", rut.ErrMinLength)
+			}
+		}
+	}
+}
+
+// fakeT records Fatalf and stops the caller like testing.T does
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = true
+	panic("fatal")
+}
@@ -0,0 +1,94 @@
+/*
+Package rutfake registers 'Rol Único Tributario' functions with gofakeit
+https://github.com/brianvoe/gofakeit
+
+importing the package is enough:
+
+	import _ "github.com/alvarolm/rut/rutfake"
+
+	gofakeit.Generate("{rut} {rutmasked}")
+
+	type Persona struct {
+		Nombre string `fake:"{name}"`
+		Rut    string `fake:"{rut}"`
+	}
+*/
+package rutfake
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/alvarolm/rut"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+var (
+	// DefaultMin is the default lower bound (inclusive) of generated 'cuerpos'
+	DefaultMin = 5000000
+
+	// DefaultMax is the default upper bound (exclusive) of generated 'cuerpos'
+	DefaultMax = 23000000
+)
+
+func init() {
+	gofakeit.AddFuncLookup("rut", gofakeit.Info{
+		Display:     "RUT",
+		Category:    "person",
+		Description: "Valid chilean 'Rol Único Tributario' in decimal point format",
+		Example:     "12.345.678-5",
+		Output:      "string",
+		Params:      params,
+		Generate: func(r *rand.Rand, m *gofakeit.MapParams, info *gofakeit.Info) (any, error) {
+			rt, err := generate(r, m, info)
+			if err != nil {
+				return nil, err
+			}
+			return rt.DecimalFormatSafe()
+		},
+	})
+
+	gofakeit.AddFuncLookup("rutmasked", gofakeit.Info{
+		Display:     "Masked RUT",
+		Category:    "person",
+		Description: "Valid chilean 'Rol Único Tributario' with the leading 'cuerpo' digits masked",
+		Example:     "**.***.678-5",
+		Output:      "string",
+		Params:      params,
+		Generate: func(r *rand.Rand, m *gofakeit.MapParams, info *gofakeit.Info) (any, error) {
+			rt, err := generate(r, m, info)
+			if err != nil {
+				return nil, err
+			}
+			return rt.MaskedFormat(), nil
+		},
+	})
+}
+
+var params = []gofakeit.Param{
+	{Field: "min", Display: "Min", Type: "int", Default: strconv.Itoa(DefaultMin), Description: "Minimum 'cuerpo' (inclusive)"},
+	{Field: "max", Display: "Max", Type: "int", Default: strconv.Itoa(DefaultMax), Description: "Maximum 'cuerpo' (exclusive)"},
+}
+
+// generate draws the 'cuerpo' from the faker source so seeded fakers
+// produce reproducible values
+func generate(r *rand.Rand, m *gofakeit.MapParams, info *gofakeit.Info) (rt rut.Rut, err error) {
+	min, err := info.GetInt(m, "min")
+	if err != nil {
+		return
+	}
+	max, err := info.GetInt(m, "max")
+	if err != nil {
+		return
+	}
+	if max <= min {
+		return "", rut.ErrInvalidRange
+	}
+
+	rt = rut.Rut(strconv.Itoa(r.Intn(max-min)+min) + "-0")
+	ai, err := rt.Validate()
+	if ai == nil {
+		return "", err
+	}
+	return rt[:len(rt)-1] + rut.Rut(string(ai.ExpectedDV)), nil
+}
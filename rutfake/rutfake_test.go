@@ -0,0 +1,24 @@
+package rutfake
+
+import (
+	"testing"
+
+	"github.com/alvarolm/rut"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+func TestLookups(t *testing.T) {
+	faker := gofakeit.New(1)
+
+	for i := 0; i < 10; i++ {
+		r := rut.Rut(faker.Generate("{rut}"))
+		if _, err := r.Validate(); err != nil {
+			t.Error(err, r)
+		}
+
+		masked := faker.Generate("{rutmasked}")
+		if masked[0] != '*' {
+			t.Error("expected masked rut, got", masked)
+		}
+	}
+}
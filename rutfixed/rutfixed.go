@@ -0,0 +1,120 @@
+/*
+Package rutfixed extracts, validates and corrects 'Rol Único Tributario'
+fields of fixed width records, like the Previred payroll files where the
+'cuerpo' and the 'digito verificador' are separate columns
+*/
+package rutfixed
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrShortRecord = errors.New("record shorter than the layout")
+	ErrLayout      = errors.New("invalid layout, expected positive widths and non negative offsets")
+)
+
+// Field locates a rut split in a 'cuerpo' and a 'digito verificador' column
+type Field struct {
+	Name string
+
+	// BodyStart and BodyWidth are the zero based byte offset and width of
+	// the 'cuerpo' column, padded with zeros or spaces
+	BodyStart, BodyWidth int
+
+	// DVStart is the byte offset of the one byte 'digito verificador' column
+	DVStart int
+}
+
+// Layout describes the rut fields of a record
+type Layout []Field
+
+func (l Layout) check(record string) error {
+	for _, f := range l {
+		if f.BodyStart < 0 || f.BodyWidth <= 0 || f.DVStart < 0 {
+			return ErrLayout
+		}
+		if len(record) < f.BodyStart+f.BodyWidth || len(record) <= f.DVStart {
+			return ErrShortRecord
+		}
+	}
+	return nil
+}
+
+// Parse validates every field of record, results are in layout order,
+// Result.Input is the extracted 'cuerpo', without its padding, and
+// 'digito verificador' joined by '-'
+func (l Layout) Parse(record string) ([]rut.Result, error) {
+	if err := l.check(record); err != nil {
+		return nil, err
+	}
+
+	results := make([]rut.Result, len(l))
+	for i, f := range l {
+		body := strings.TrimLeft(strings.TrimSpace(record[f.BodyStart:f.BodyStart+f.BodyWidth]), "0")
+		results[i] = rut.Check(body + "-" + record[f.DVStart:f.DVStart+1])
+	}
+	return results, nil
+}
+
+// Correct returns record with every wrong 'digito verificador' replaced
+// by the expected one, fields that can't be computed are left untouched
+func (l Layout) Correct(record string) (corrected string, results []rut.Result, err error) {
+	if results, err = l.Parse(record); err != nil {
+		return
+	}
+
+	b := []byte(record)
+	for i, res := range results {
+		if res.Err == rut.ErrinvalidDV {
+			b[l[i].DVStart] = byte(res.ExpectedDV)
+		}
+	}
+	return string(b), results, nil
+}
+
+// CorrectLines streams the records of r to w, one per line, applying
+// Correct. line endings are preserved and the blank lines are written
+// unchanged, the report accounts every field as found,
+// Report.Errors[rut.Code(rut.ErrinvalidDV)] are the corrected ones
+func (l Layout) CorrectLines(w io.Writer, r io.Reader) (report rut.Report, err error) {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+
+	for {
+		var line string
+		line, err = br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		eof := err == io.EOF
+
+		if len(line) > 0 {
+			record := strings.TrimRight(line, "\r\n")
+
+			corrected := record
+			if strings.TrimSpace(record) != "" {
+				c, results, cerr := l.Correct(record)
+				if cerr != nil {
+					return report, cerr
+				}
+				for _, res := range results {
+					report.Add(res)
+				}
+				corrected = c
+			}
+			if _, err = bw.WriteString(corrected + line[len(record):]); err != nil {
+				return
+			}
+		}
+
+		if eof {
+			return report, bw.Flush()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package rutfixed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+var layout = Layout{
+	{Name: "trabajador", BodyStart: 0, BodyWidth: 11, DVStart: 11},
+	{Name: "empleador", BodyStart: 12, BodyWidth: 11, DVStart: 23},
+}
+
+func TestParse(t *testing.T) {
+	results, err := layout.Parse("00011111111100012345678KPEREZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Err != nil || results[0].Rut != "11111111-1" {
+		t.Error("unexpected", results[0])
+	}
+	if results[1].Err != rut.ErrinvalidDV || results[1].ExpectedDV != '5' {
+		t.Error("unexpected", results[1])
+	}
+
+	if _, err := layout.Parse("000111111111"); err != ErrShortRecord {
+		t.Error("expected", ErrShortRecord, "got", err)
+	}
+}
+
+func TestCorrectLines(t *testing.T) {
+	in := "00011111111100012345678KPEREZ\r\n\r\n   111111112   123456780SOTO\n  \n"
+
+	var out bytes.Buffer
+	report, err := layout.CorrectLines(&out, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Errors[rut.Code(rut.ErrinvalidDV)] != 3 {
+		t.Error("unexpected report", report)
+	}
+
+	expected := "000111111111000123456785PEREZ\r\n\r\n   111111111   123456785SOTO\n  \n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
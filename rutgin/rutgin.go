@@ -0,0 +1,65 @@
+/*
+Package rutgin validates the rut fields of the Gin bound structs
+
+	rutgin.Register()
+
+	r.POST("/clients", func(c *gin.Context) {
+		var req struct {
+			RUT rut.Rut `json:"rut" binding:"rut"`
+		}
+		if !rutgin.Bind(c, &req) {
+			return
+		}
+		...
+	})
+
+invalid ruts are answered with 422 and the other binding failures with
+400, both with a ruthttp.ErrorResponse body. rut.Rut fields are decoded
+normalized, eg. "12.345.678-5" binds as "12345678-5"
+*/
+package rutgin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/ruthttp"
+	"github.com/alvarolm/rut/rutvalidator"
+)
+
+var ErrEngine = errors.New("gin validator engine isn't a go-playground/validator")
+
+// Register adds the rutvalidator.Tag to the gin validator engine
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return ErrEngine
+	}
+	return rutvalidator.Register(v)
+}
+
+// Bind binds the request to obj with ShouldBind, on failure it writes the
+// ErrorResponse, aborts the handlers chain and returns false
+func Bind(c *gin.Context, obj any) bool {
+	err := c.ShouldBind(obj)
+	if err == nil {
+		return true
+	}
+
+	var rerr *rut.Error
+	status := http.StatusUnprocessableEntity
+	if verr := rutvalidator.Error(err); verr != nil {
+		err = verr
+	} else if !errors.As(err, &rerr) {
+		// rut.Rut fields fail while decoding, their errors are kept
+		status, err = http.StatusBadRequest, rut.NewError(ruthttp.CodeBadRequest, err.Error())
+	}
+	c.Abort()
+	ruthttp.WriteError(c.Writer, status, err)
+	return false
+}
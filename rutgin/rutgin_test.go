@@ -0,0 +1,69 @@
+package rutgin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/ruthttp"
+)
+
+type request struct {
+	RUT rut.Rut `json:"rut" binding:"rut"`
+	Ref string  `json:"ref" binding:"omitempty,rut"`
+}
+
+func serve(t *testing.T, body string) (*httptest.ResponseRecorder, ruthttp.ErrorResponse) {
+	gin.SetMode(gin.TestMode)
+	if err := Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.POST("/", func(c *gin.Context) {
+		var req request
+		if !Bind(c, &req) {
+			return
+		}
+		c.String(http.StatusOK, string(req.RUT))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	var resp ruthttp.ErrorResponse
+	if w.Code != http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err, w.Body.String())
+		}
+	}
+	return w, resp
+}
+
+func TestBind(t *testing.T) {
+	if w, _ := serve(t, `{"rut":"12.345.678-5"}`); w.Code != http.StatusOK || w.Body.String() != "12345678-5" {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp := serve(t, `{"rut":"12.345.678-0"}`)
+	if w.Code != http.StatusUnprocessableEntity || resp.Error.Code != "invalid_dv" {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp = serve(t, `{"rut":"12.345.678-5","ref":"1-9"}`)
+	if w.Code != http.StatusUnprocessableEntity || resp.Error.Code != "min_length" || !strings.Contains(resp.Error.Message, "Ref") {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+
+	w, resp = serve(t, `{"rut":`)
+	if w.Code != http.StatusBadRequest || resp.Error.Code != ruthttp.CodeBadRequest {
+		t.Error("unexpected response", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Package rutgorm registers the GORM serializers of rut.Rut and *rut.Rut
+fields, ruts are validated on save and normalized on load
+
+	import _ "github.com/alvarolm/rut/rutgorm"
+
+	type Client struct {
+		ID  uint
+		RUT rut.Rut  `gorm:"serializer:rut"`    // varchar, '12345678-5'
+		Ref *rut.Rut `gorm:"serializer:rutnum"` // integer 'cuerpo', 12345678
+	}
+
+empty ruts and nil pointers are stored as NULL, and NULL loads as them
+*/
+package rutgorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/alvarolm/rut"
+)
+
+func init() {
+	schema.RegisterSerializer("rut", StringSerializer{})
+	schema.RegisterSerializer("rutnum", NumericSerializer{})
+}
+
+// StringSerializer, the "rut" serializer, stores the normalized ruts
+type StringSerializer struct{}
+
+func (StringSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var in string
+	switch v := dbValue.(type) {
+	case nil:
+		return set(ctx, field, dst, "")
+	case string:
+		in = v
+	case []byte:
+		in = string(v)
+	default:
+		return fmt.Errorf("rutgorm: cannot scan %T into %s", dbValue, field.Name)
+	}
+	r, err := rut.Parse(in)
+	if err != nil {
+		return err
+	}
+	return set(ctx, field, dst, r)
+}
+
+func (StringSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	r, ok := value(fieldValue)
+	if !ok {
+		return nil, nil
+	}
+	if _, err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return string(r), nil
+}
+
+// NumericSerializer, the "rutnum" serializer, stores the 'cuerpos'
+type NumericSerializer struct{}
+
+func (NumericSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) (err error) {
+	var body int64
+	switch v := dbValue.(type) {
+	case nil:
+		return set(ctx, field, dst, "")
+	case int64:
+		body = v
+	case int32:
+		body = int64(v)
+	case int:
+		body = int64(v)
+	case []byte:
+		body, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		body, err = strconv.ParseInt(v, 10, 64)
+	default:
+		return fmt.Errorf("rutgorm: cannot scan %T into %s", dbValue, field.Name)
+	}
+	if err != nil {
+		return rut.ErrExpectedDigit
+	}
+	r, err := rut.FromBody(int(body))
+	if err != nil {
+		return err
+	}
+	return set(ctx, field, dst, r)
+}
+
+func (NumericSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	r, ok := value(fieldValue)
+	if !ok {
+		return nil, nil
+	}
+	body, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+	return int64(body), nil
+}
+
+// value returns the rut of a rut.Rut or *rut.Rut field, false when it's
+// nil or empty
+func value(fieldValue any) (r rut.Rut, ok bool) {
+	switch v := fieldValue.(type) {
+	case rut.Rut:
+		r = v
+	case *rut.Rut:
+		if v != nil {
+			r = *v
+		}
+	}
+	return r, r != ""
+}
+
+// set stores r in the rut.Rut or *rut.Rut field, an empty r sets nil pointers
+func set(ctx context.Context, field *schema.Field, dst reflect.Value, r rut.Rut) error {
+	fv := field.ReflectValueOf(ctx, dst)
+	switch fv.Type() {
+	case reflect.TypeOf(r):
+		fv.Set(reflect.ValueOf(r))
+	case reflect.TypeOf(&r):
+		if r == "" {
+			fv.Set(reflect.Zero(fv.Type()))
+		} else {
+			fv.Set(reflect.ValueOf(&r))
+		}
+	default:
+		return fmt.Errorf("rutgorm: %s is a %s, expected rut.Rut or *rut.Rut", field.Name, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package rutgorm
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/alvarolm/rut"
+)
+
+type client struct {
+	ID  uint
+	RUT rut.Rut  `gorm:"serializer:rut"`
+	Ref *rut.Rut `gorm:"serializer:rutnum"`
+}
+
+func fields(t *testing.T) (str, num *schema.Field) {
+	s, err := schema.Parse(&client{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.LookUpField("RUT"), s.LookUpField("Ref")
+}
+
+func TestValue(t *testing.T) {
+	str, num := fields(t)
+	ctx := context.Background()
+	ref := rut.Rut("13.117.182-k")
+	c := client{RUT: "12.345.678-5", Ref: &ref}
+	dst := reflect.ValueOf(&c).Elem()
+
+	if v, err := (StringSerializer{}).Value(ctx, str, dst, c.RUT); err != nil || v != "12345678-5" {
+		t.Error("unexpected value", v, err)
+	}
+	if v, err := (NumericSerializer{}).Value(ctx, num, dst, c.Ref); err != nil || v != int64(13117182) {
+		t.Error("unexpected value", v, err)
+	}
+
+	if _, err := (StringSerializer{}).Value(ctx, str, dst, rut.Rut("12345678-0")); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if v, err := (StringSerializer{}).Value(ctx, str, dst, rut.Rut("")); err != nil || v != nil {
+		t.Error("expected NULL, got", v, err)
+	}
+	if v, err := (NumericSerializer{}).Value(ctx, num, dst, (*rut.Rut)(nil)); err != nil || v != nil {
+		t.Error("expected NULL, got", v, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	str, num := fields(t)
+	ctx := context.Background()
+	var c client
+	dst := reflect.ValueOf(&c).Elem()
+
+	if err := (StringSerializer{}).Scan(ctx, str, dst, []byte("12.345.678-5")); err != nil || c.RUT != "12345678-5" {
+		t.Error("unexpected rut", c.RUT, err)
+	}
+	if err := (NumericSerializer{}).Scan(ctx, num, dst, int64(13117182)); err != nil || c.Ref == nil || *c.Ref != "13117182-K" {
+		t.Error("unexpected ref", c.Ref, err)
+	}
+
+	if err := (NumericSerializer{}).Scan(ctx, num, dst, nil); err != nil || c.Ref != nil {
+		t.Error("expected a nil ref, got", c.Ref, err)
+	}
+	if err := (StringSerializer{}).Scan(ctx, str, dst, "12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if err := (NumericSerializer{}).Scan(ctx, num, dst, "12"); err != rut.ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+	if err := (StringSerializer{}).Scan(ctx, str, dst, 1.5); err == nil {
+		t.Error("expected an error scanning a float")
+	}
+}
@@ -0,0 +1,24 @@
+package rutgrpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/alvarolm/rut/rutgrpc/rutpb"
+)
+
+// NewGateway returns the REST mapping of s defined by the google.api.http
+// options of rutpb/rut.proto: every method is a POST of its request as JSON
+// to /v1/rut:<method>, eg. /v1/rut:validate, and the errors are answered
+// as the JSON of their status. s is called in process so the gRPC
+// interceptors don't apply, use rutpb.RegisterRutServiceHandlerFromEndpoint
+// to proxy a running server instead
+func NewGateway(ctx context.Context, s rutpb.RutServiceServer, opts ...runtime.ServeMuxOption) (http.Handler, error) {
+	mux := runtime.NewServeMux(opts...)
+	if err := rutpb.RegisterRutServiceHandlerServer(ctx, mux, s); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
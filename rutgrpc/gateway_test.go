@@ -0,0 +1,85 @@
+package rutgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/alvarolm/rut/rutgrpc/rutpb"
+)
+
+func TestGateway(t *testing.T) {
+	gw, err := NewGateway(context.Background(), NewServer(Options{MaxGenerate: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	post := func(path, body string, v any) int {
+		resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatal(path, err)
+		}
+		return resp.StatusCode
+	}
+
+	var v struct {
+		Valid      bool
+		ExpectedDv string
+		Code       string
+	}
+	if code := post("/v1/rut:validate", `{"rut":"12345678-0"}`, &v); code != http.StatusOK || v.Valid || v.ExpectedDv != "5" || v.Code != "invalid_dv" {
+		t.Error("unexpected response", code, v)
+	}
+
+	var batch struct {
+		Report struct{ Total, Valid, Duplicates string }
+	}
+	if code := post("/v1/rut:validateBatch", `{"ruts":["11111111-1","11.111.111-1"]}`, &batch); code != http.StatusOK || batch.Report.Total != "2" || batch.Report.Duplicates != "1" {
+		t.Error("unexpected response", code, batch)
+	}
+
+	var generated struct{ Ruts []string }
+	if code := post("/v1/rut:generate", `{"n":3,"kind":"KIND_COMPANY"}`, &generated); code != http.StatusOK || len(generated.Ruts) != 3 {
+		t.Error("unexpected response", code, generated)
+	}
+
+	var formatted struct{ Formatted string }
+	if code := post("/v1/rut:format", `{"rut":"11111111-1","style":"STYLE_DOTTED"}`, &formatted); code != http.StatusOK || formatted.Formatted != "11.111.111-1" {
+		t.Error("unexpected response", code, formatted)
+	}
+
+	var st struct {
+		Code    int
+		Details []struct{ Reason, Domain string }
+	}
+	if code := post("/v1/rut:format", `{"rut":"12345678-0"}`, &st); code != http.StatusBadRequest || len(st.Details) != 1 || st.Details[0].Reason != "invalid_dv" || st.Details[0].Domain != ErrorDomain {
+		t.Error("unexpected error", code, st)
+	}
+	if code := post("/v1/rut:generate", `{"n":11}`, &st); code != http.StatusBadRequest {
+		t.Error("expected 400, got", code)
+	}
+}
+
+func TestGatewayOptions(t *testing.T) {
+	methods := rutpb.File_rut_proto.Services().ByName("RutService").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		m := methods.Get(i)
+		rule, _ := proto.GetExtension(m.Options(), annotations.E_Http).(*annotations.HttpRule)
+		name := string(m.Name())
+		if expected := "/v1/rut:" + strings.ToLower(name[:1]) + name[1:]; rule.GetPost() != expected || rule.GetBody() != "*" {
+			t.Error(name, "expected POST", expected, "got", rule)
+		}
+	}
+}
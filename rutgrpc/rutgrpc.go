@@ -0,0 +1,228 @@
+// Package rutgrpc implements the rutpb.RutService gRPC service
+package rutgrpc
+
+// GOOGLEAPIS is a checkout of github.com/googleapis/googleapis, for the
+// google/api/annotations.proto imported by rut.proto
+//go:generate protoc -I rutpb -I ${GOOGLEAPIS} --go_out=rutpb --go_opt=paths=source_relative --go-grpc_out=rutpb --go-grpc_opt=paths=source_relative --grpc-gateway_out=rutpb --grpc-gateway_opt=paths=source_relative rut.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutgrpc/rutpb"
+)
+
+// Defaults of the Options fields left unset
+const (
+	DefaultMaxBatch    = 1000
+	DefaultMaxGenerate = 10000
+)
+
+// ErrorDomain is the domain of the errdetails.ErrorInfo attached to the
+// InvalidArgument statuses, its Reason is the rut error code
+const ErrorDomain = "rut"
+
+// Options configures the Server
+type Options struct {
+	// MaxBatch bounds the ruts of a single ValidateBatch call
+	MaxBatch int
+
+	// MaxGenerate bounds the n of a single Generate call
+	MaxGenerate int
+
+	// Workers is passed to rut.ValidateBatch
+	Workers int
+}
+
+// Server implements rutpb.RutServiceServer
+type Server struct {
+	rutpb.UnimplementedRutServiceServer
+	opts Options
+}
+
+// NewServer returns a Server, register it with rutpb.RegisterRutServiceServer
+func NewServer(opts Options) *Server {
+	if opts.MaxBatch <= 0 {
+		opts.MaxBatch = DefaultMaxBatch
+	}
+	if opts.MaxGenerate <= 0 {
+		opts.MaxGenerate = DefaultMaxGenerate
+	}
+	return &Server{opts: opts}
+}
+
+// invalidArgument returns an InvalidArgument status carrying rut.Code(err)
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	if ds, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: rut.Code(err), Domain: ErrorDomain}); derr == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// ToValidateResponse converts a rut.Result
+func ToValidateResponse(res rut.Result) (v *rutpb.ValidateResponse) {
+	v = &rutpb.ValidateResponse{
+		Input:      res.Input,
+		Valid:      res.Valid(),
+		Normalized: string(res.Rut),
+		Code:       rut.Code(res.Err),
+	}
+	if res.ExpectedDV != 0 {
+		v.ExpectedDv = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		v.Error = res.Err.Error()
+	}
+	return
+}
+
+// ToReport converts a rut.Report
+func ToReport(rp *rut.Report) *rutpb.Report {
+	errs := make(map[string]int64, len(rp.Errors))
+	for code, n := range rp.Errors {
+		errs[code] = int64(n)
+	}
+	return &rutpb.Report{
+		Total:      int64(rp.Total),
+		Valid:      int64(rp.Valid),
+		Invalid:    int64(rp.Invalid),
+		Duplicates: int64(rp.Duplicates),
+		Errors:     errs,
+	}
+}
+
+// ToProto validates r and converts it, 'cuerpos' above the uint32 Body
+// field fail as rut.ErrMaxLength
+func ToProto(r rut.Rut) (*rutpb.Rut, error) {
+	body, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+	if body > math.MaxUint32 {
+		return nil, rut.ErrMaxLength
+	}
+	return &rutpb.Rut{Body: uint32(body), Dv: string(r[len(r)-1])}, nil
+}
+
+// FromProto validates p and returns its normalized rut, a lowercase 'k'
+// is accepted
+func FromProto(p *rutpb.Rut) (rut.Rut, error) {
+	if len(p.GetDv()) != 1 {
+		return "", rut.ErrInvalidDVchar
+	}
+	return rut.Parse(strconv.FormatUint(uint64(p.GetBody()), 10) + "-" + p.GetDv())
+}
+
+var kinds = map[rutpb.Kind]rut.Kind{
+	rutpb.Kind_KIND_UNSPECIFIED: rut.KindAny,
+	rutpb.Kind_KIND_PERSON:      rut.KindPerson,
+	rutpb.Kind_KIND_COMPANY:     rut.KindCompany,
+}
+
+var styles = map[rutpb.Style]rut.Style{
+	rutpb.Style_STYLE_UNSPECIFIED: rut.StyleDotted,
+	rutpb.Style_STYLE_CANONICAL:   rut.StyleCanonical,
+	rutpb.Style_STYLE_DOTTED:      rut.StyleDotted,
+	rutpb.Style_STYLE_FIXED_WIDTH: rut.StyleFixedWidth,
+	rutpb.Style_STYLE_NUMERIC:     rut.StyleNumeric,
+	rutpb.Style_STYLE_MASKED:      rut.StyleMasked,
+}
+
+func style(s rutpb.Style) (rut.Style, error) {
+	st, ok := styles[s]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "unknown style %d", s)
+	}
+	return st, nil
+}
+
+// Validate validates a single rut, an invalid rut isn't an error
+func (s *Server) Validate(ctx context.Context, req *rutpb.ValidateRequest) (*rutpb.ValidateResponse, error) {
+	return ToValidateResponse(rut.Check(req.GetRut())), nil
+}
+
+// ValidateBatch validates every rut and summarizes the results
+func (s *Server) ValidateBatch(ctx context.Context, req *rutpb.ValidateBatchRequest) (*rutpb.ValidateBatchResponse, error) {
+	if len(req.GetRuts()) > s.opts.MaxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ruts per request", s.opts.MaxBatch)
+	}
+
+	results := rut.ValidateBatch(ctx, req.GetRuts(), s.opts.Workers)
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	resp := &rutpb.ValidateBatchResponse{
+		Results: make([]*rutpb.ValidateResponse, len(results)),
+		Report:  ToReport(rut.NewReport(results...)),
+	}
+	for i, res := range results {
+		resp.Results[i] = ToValidateResponse(res)
+	}
+	return resp, nil
+}
+
+// Generate generates random valid ruts
+func (s *Server) Generate(ctx context.Context, req *rutpb.GenerateRequest) (*rutpb.GenerateResponse, error) {
+	n := int(req.GetN())
+	if n == 0 {
+		n = 1
+	}
+	if n < 0 || n > s.opts.MaxGenerate {
+		return nil, status.Errorf(codes.InvalidArgument, "n must be between 1 and %d", s.opts.MaxGenerate)
+	}
+	kind, ok := kinds[req.GetKind()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown kind %d", req.GetKind())
+	}
+	st, err := style(req.GetStyle())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &rutpb.GenerateResponse{Ruts: make([]string, 0, n)}
+	err = rut.GenerateN(ctx, n, rut.GenerateOptions{
+		Min:    int(req.GetMin()),
+		Max:    int(req.GetMax()),
+		Kind:   kind,
+		Unique: req.GetUnique(),
+		Each: func(g rut.Rut) (err error) {
+			f, err := g.Format(rut.FormatOptions{Style: st})
+			resp.Ruts = append(resp.Ruts, f)
+			return
+		},
+	})
+	switch {
+	case err == nil:
+		return resp, nil
+	case ctx.Err() != nil:
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, rut.ErrExhausted):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// Format rewrites a valid rut in the requested style, invalid ruts are
+// answered with InvalidArgument
+func (s *Server) Format(ctx context.Context, req *rutpb.FormatRequest) (*rutpb.FormatResponse, error) {
+	st, err := style(req.GetStyle())
+	if err != nil {
+		return nil, err
+	}
+	r := rut.Rut(req.GetRut())
+	f, err := r.Format(rut.FormatOptions{Style: st})
+	if err != nil {
+		return nil, invalidArgument(fmt.Errorf("rut %q: %w", req.GetRut(), err))
+	}
+	return &rutpb.FormatResponse{Formatted: f}, nil
+}
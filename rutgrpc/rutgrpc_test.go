@@ -0,0 +1,110 @@
+package rutgrpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutgrpc/rutpb"
+)
+
+func TestValidate(t *testing.T) {
+	s := NewServer(Options{})
+
+	v, err := s.Validate(context.Background(), &rutpb.ValidateRequest{Rut: "12345678-0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Valid || v.Code != "invalid_dv" || v.ExpectedDv != "5" {
+		t.Error("unexpected response", v)
+	}
+
+	batch, err := s.ValidateBatch(context.Background(), &rutpb.ValidateBatchRequest{Ruts: []string{"11111111-1", "11.111.111-1", "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := batch.Report; r.Total != 3 || r.Valid != 2 || r.Duplicates != 1 || r.Errors["min_length"] != 1 {
+		t.Error("unexpected report", r)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := NewServer(Options{MaxGenerate: 10})
+
+	resp, err := s.Generate(context.Background(), &rutpb.GenerateRequest{N: 10, Kind: rutpb.Kind_KIND_COMPANY, Style: rutpb.Style_STYLE_CANONICAL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Ruts) != 10 {
+		t.Error("expected 10 ruts, got", len(resp.Ruts))
+	}
+
+	if _, err := s.Generate(context.Background(), &rutpb.GenerateRequest{N: 11}); status.Code(err) != codes.InvalidArgument {
+		t.Error("expected InvalidArgument, got", err)
+	}
+	if _, err := s.Generate(context.Background(), &rutpb.GenerateRequest{N: 10, Min: 1000000, Max: 1000005, Unique: true}); status.Code(err) != codes.ResourceExhausted {
+		t.Error("expected ResourceExhausted, got", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	s := NewServer(Options{})
+
+	resp, err := s.Format(context.Background(), &rutpb.FormatRequest{Rut: "11111111-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Formatted != "11.111.111-1" {
+		t.Error("unexpected formatted", resp.Formatted)
+	}
+
+	_, err = s.Format(context.Background(), &rutpb.FormatRequest{Rut: "12345678-0"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatal("expected InvalidArgument, got", err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == "invalid_dv" && info.Domain == ErrorDomain {
+			return
+		}
+	}
+	t.Error("missing error info", st.Details())
+}
+
+func TestProto(t *testing.T) {
+	p, err := ToProto("13.117.182-k")
+	if err != nil || p.GetBody() != 13117182 || p.GetDv() != "K" {
+		t.Fatal("unexpected message", p, err)
+	}
+	if r, err := FromProto(p); err != nil || r != "13117182-K" {
+		t.Error("unexpected rut", r, err)
+	}
+	if r, err := FromProto(&rutpb.Rut{Body: 13117182, Dv: "k"}); err != nil || r != "13117182-K" {
+		t.Error("unexpected rut", r, err)
+	}
+
+	if _, err := ToProto("123.456.789-2"); err != rut.ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+	if _, err := ToProto("12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if _, err := FromProto(&rutpb.Rut{Body: 12345678, Dv: "0"}); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	defer func(max int) { rut.MaxRutlength = max }(rut.MaxRutlength)
+	rut.MaxRutlength = 14
+	if _, err := ToProto("4294967296-9"); err != rut.ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+
+	for _, p := range []*rutpb.Rut{nil, {Body: 12345678}, {Body: 12345678, Dv: "55"}} {
+		if _, err := FromProto(p); err != rut.ErrInvalidDVchar {
+			t.Error(p, "expected ErrInvalidDVchar, got", err)
+		}
+	}
+}
@@ -0,0 +1,860 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: rut.proto
+
+package rutpb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Kind int32
+
+const (
+	Kind_KIND_UNSPECIFIED Kind = 0
+	Kind_KIND_PERSON      Kind = 1
+	Kind_KIND_COMPANY     Kind = 2
+)
+
+// Enum value maps for Kind.
+var (
+	Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_PERSON",
+		2: "KIND_COMPANY",
+	}
+	Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_PERSON":      1,
+		"KIND_COMPANY":     2,
+	}
+)
+
+func (x Kind) Enum() *Kind {
+	p := new(Kind)
+	*p = x
+	return p
+}
+
+func (x Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_rut_proto_enumTypes[0].Descriptor()
+}
+
+func (Kind) Type() protoreflect.EnumType {
+	return &file_rut_proto_enumTypes[0]
+}
+
+func (x Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Kind.Descriptor instead.
+func (Kind) EnumDescriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{0}
+}
+
+type Style int32
+
+const (
+	// dotted
+	Style_STYLE_UNSPECIFIED Style = 0
+	Style_STYLE_CANONICAL   Style = 1
+	Style_STYLE_DOTTED      Style = 2
+	Style_STYLE_FIXED_WIDTH Style = 3
+	Style_STYLE_NUMERIC     Style = 4
+	Style_STYLE_MASKED      Style = 5
+)
+
+// Enum value maps for Style.
+var (
+	Style_name = map[int32]string{
+		0: "STYLE_UNSPECIFIED",
+		1: "STYLE_CANONICAL",
+		2: "STYLE_DOTTED",
+		3: "STYLE_FIXED_WIDTH",
+		4: "STYLE_NUMERIC",
+		5: "STYLE_MASKED",
+	}
+	Style_value = map[string]int32{
+		"STYLE_UNSPECIFIED": 0,
+		"STYLE_CANONICAL":   1,
+		"STYLE_DOTTED":      2,
+		"STYLE_FIXED_WIDTH": 3,
+		"STYLE_NUMERIC":     4,
+		"STYLE_MASKED":      5,
+	}
+)
+
+func (x Style) Enum() *Style {
+	p := new(Style)
+	*p = x
+	return p
+}
+
+func (x Style) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Style) Descriptor() protoreflect.EnumDescriptor {
+	return file_rut_proto_enumTypes[1].Descriptor()
+}
+
+func (Style) Type() protoreflect.EnumType {
+	return &file_rut_proto_enumTypes[1]
+}
+
+func (x Style) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Style.Descriptor instead.
+func (Style) EnumDescriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{1}
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rut           string                 `protobuf:"bytes,1,opt,name=rut,proto3" json:"rut,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_rut_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateRequest) GetRut() string {
+	if x != nil {
+		return x.Rut
+	}
+	return ""
+}
+
+type ValidateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Input string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Valid bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	// canonical form, set when the rut is valid
+	Normalized string `protobuf:"bytes,3,opt,name=normalized,proto3" json:"normalized,omitempty"`
+	// set when the 'cuerpo' is valid
+	ExpectedDv string `protobuf:"bytes,4,opt,name=expected_dv,json=expectedDv,proto3" json:"expected_dv,omitempty"`
+	// stable error code, empty when the rut is valid
+	Code          string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_rut_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateResponse) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetNormalized() string {
+	if x != nil {
+		return x.Normalized
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetExpectedDv() string {
+	if x != nil {
+		return x.ExpectedDv
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ValidateBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ruts          []string               `protobuf:"bytes,1,rep,name=ruts,proto3" json:"ruts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchRequest) Reset() {
+	*x = ValidateBatchRequest{}
+	mi := &file_rut_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchRequest) ProtoMessage() {}
+
+func (x *ValidateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchRequest.ProtoReflect.Descriptor instead.
+func (*ValidateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidateBatchRequest) GetRuts() []string {
+	if x != nil {
+		return x.Ruts
+	}
+	return nil
+}
+
+type Report struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Total      int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Valid      int64                  `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Invalid    int64                  `protobuf:"varint,3,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Duplicates int64                  `protobuf:"varint,4,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// invalid ruts by error code
+	Errors        map[string]int64 `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_rut_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Report) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Report) GetValid() int64 {
+	if x != nil {
+		return x.Valid
+	}
+	return 0
+}
+
+func (x *Report) GetInvalid() int64 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *Report) GetDuplicates() int64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *Report) GetErrors() map[string]int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ValidateBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ValidateResponse    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Report        *Report                `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchResponse) Reset() {
+	*x = ValidateBatchResponse{}
+	mi := &file_rut_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchResponse) ProtoMessage() {}
+
+func (x *ValidateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidateBatchResponse) GetResults() []*ValidateResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ValidateBatchResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type GenerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	N     int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Kind  Kind                   `protobuf:"varint,2,opt,name=kind,proto3,enum=rut.v1.Kind" json:"kind,omitempty"`
+	// min and max bound the 'cuerpos' to [min, max),
+	// when both are 0 the default range of kind is used
+	Min           int64 `protobuf:"varint,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+	Unique        bool  `protobuf:"varint,5,opt,name=unique,proto3" json:"unique,omitempty"`
+	Style         Style `protobuf:"varint,6,opt,name=style,proto3,enum=rut.v1.Style" json:"style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_rut_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GenerateRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetKind() Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Kind_KIND_UNSPECIFIED
+}
+
+func (x *GenerateRequest) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *GenerateRequest) GetStyle() Style {
+	if x != nil {
+		return x.Style
+	}
+	return Style_STYLE_UNSPECIFIED
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ruts          []string               `protobuf:"bytes,1,rep,name=ruts,proto3" json:"ruts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_rut_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GenerateResponse) GetRuts() []string {
+	if x != nil {
+		return x.Ruts
+	}
+	return nil
+}
+
+type FormatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rut           string                 `protobuf:"bytes,1,opt,name=rut,proto3" json:"rut,omitempty"`
+	Style         Style                  `protobuf:"varint,2,opt,name=style,proto3,enum=rut.v1.Style" json:"style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FormatRequest) Reset() {
+	*x = FormatRequest{}
+	mi := &file_rut_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FormatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FormatRequest) ProtoMessage() {}
+
+func (x *FormatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FormatRequest.ProtoReflect.Descriptor instead.
+func (*FormatRequest) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FormatRequest) GetRut() string {
+	if x != nil {
+		return x.Rut
+	}
+	return ""
+}
+
+func (x *FormatRequest) GetStyle() Style {
+	if x != nil {
+		return x.Style
+	}
+	return Style_STYLE_UNSPECIFIED
+}
+
+type FormatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Formatted     string                 `protobuf:"bytes,1,opt,name=formatted,proto3" json:"formatted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FormatResponse) Reset() {
+	*x = FormatResponse{}
+	mi := &file_rut_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FormatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FormatResponse) ProtoMessage() {}
+
+func (x *FormatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FormatResponse.ProtoReflect.Descriptor instead.
+func (*FormatResponse) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FormatResponse) GetFormatted() string {
+	if x != nil {
+		return x.Formatted
+	}
+	return ""
+}
+
+// Rut is a valid 'Rol Único Tributario', use it instead of formatted strings
+type Rut struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 'cuerpo'
+	Body uint32 `protobuf:"varint,1,opt,name=body,proto3" json:"body,omitempty"`
+	// 'digito verificador', a digit or K
+	Dv            string `protobuf:"bytes,2,opt,name=dv,proto3" json:"dv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rut) Reset() {
+	*x = Rut{}
+	mi := &file_rut_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rut) ProtoMessage() {}
+
+func (x *Rut) ProtoReflect() protoreflect.Message {
+	mi := &file_rut_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rut.ProtoReflect.Descriptor instead.
+func (*Rut) Descriptor() ([]byte, []int) {
+	return file_rut_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Rut) GetBody() uint32 {
+	if x != nil {
+		return x.Body
+	}
+	return 0
+}
+
+func (x *Rut) GetDv() string {
+	if x != nil {
+		return x.Dv
+	}
+	return ""
+}
+
+var File_rut_proto protoreflect.FileDescriptor
+
+const file_rut_proto_rawDesc = "" +
+	"\n" +
+	"\trut.proto\x12\x06rut.v1\x1a\x1cgoogle/api/annotations.proto\"#\n" +
+	"\x0fValidateRequest\x12\x10\n" +
+	"\x03rut\x18\x01 \x01(\tR\x03rut\"\xa9\x01\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x1e\n" +
+	"\n" +
+	"normalized\x18\x03 \x01(\tR\n" +
+	"normalized\x12\x1f\n" +
+	"\vexpected_dv\x18\x04 \x01(\tR\n" +
+	"expectedDv\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"*\n" +
+	"\x14ValidateBatchRequest\x12\x12\n" +
+	"\x04ruts\x18\x01 \x03(\tR\x04ruts\"\xdd\x01\n" +
+	"\x06Report\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\x03R\x05valid\x12\x18\n" +
+	"\ainvalid\x18\x03 \x01(\x03R\ainvalid\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x04 \x01(\x03R\n" +
+	"duplicates\x122\n" +
+	"\x06errors\x18\x05 \x03(\v2\x1a.rut.v1.Report.ErrorsEntryR\x06errors\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"s\n" +
+	"\x15ValidateBatchResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.rut.v1.ValidateResponseR\aresults\x12&\n" +
+	"\x06report\x18\x02 \x01(\v2\x0e.rut.v1.ReportR\x06report\"\xa2\x01\n" +
+	"\x0fGenerateRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12 \n" +
+	"\x04kind\x18\x02 \x01(\x0e2\f.rut.v1.KindR\x04kind\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x03R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x03R\x03max\x12\x16\n" +
+	"\x06unique\x18\x05 \x01(\bR\x06unique\x12#\n" +
+	"\x05style\x18\x06 \x01(\x0e2\r.rut.v1.StyleR\x05style\"&\n" +
+	"\x10GenerateResponse\x12\x12\n" +
+	"\x04ruts\x18\x01 \x03(\tR\x04ruts\"F\n" +
+	"\rFormatRequest\x12\x10\n" +
+	"\x03rut\x18\x01 \x01(\tR\x03rut\x12#\n" +
+	"\x05style\x18\x02 \x01(\x0e2\r.rut.v1.StyleR\x05style\".\n" +
+	"\x0eFormatResponse\x12\x1c\n" +
+	"\tformatted\x18\x01 \x01(\tR\tformatted\")\n" +
+	"\x03Rut\x12\x12\n" +
+	"\x04body\x18\x01 \x01(\rR\x04body\x12\x0e\n" +
+	"\x02dv\x18\x02 \x01(\tR\x02dv*?\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vKIND_PERSON\x10\x01\x12\x10\n" +
+	"\fKIND_COMPANY\x10\x02*\x81\x01\n" +
+	"\x05Style\x12\x15\n" +
+	"\x11STYLE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSTYLE_CANONICAL\x10\x01\x12\x10\n" +
+	"\fSTYLE_DOTTED\x10\x02\x12\x15\n" +
+	"\x11STYLE_FIXED_WIDTH\x10\x03\x12\x11\n" +
+	"\rSTYLE_NUMERIC\x10\x04\x12\x10\n" +
+	"\fSTYLE_MASKED\x10\x052\x88\x03\n" +
+	"\n" +
+	"RutService\x12Z\n" +
+	"\bValidate\x12\x17.rut.v1.ValidateRequest\x1a\x18.rut.v1.ValidateResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/rut:validate\x12n\n" +
+	"\rValidateBatch\x12\x1c.rut.v1.ValidateBatchRequest\x1a\x1d.rut.v1.ValidateBatchResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/rut:validateBatch\x12Z\n" +
+	"\bGenerate\x12\x17.rut.v1.GenerateRequest\x1a\x18.rut.v1.GenerateResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/rut:generate\x12R\n" +
+	"\x06Format\x12\x15.rut.v1.FormatRequest\x1a\x16.rut.v1.FormatResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/rut:formatB'Z%github.com/alvarolm/rut/rutgrpc/rutpbb\x06proto3"
+
+var (
+	file_rut_proto_rawDescOnce sync.Once
+	file_rut_proto_rawDescData []byte
+)
+
+func file_rut_proto_rawDescGZIP() []byte {
+	file_rut_proto_rawDescOnce.Do(func() {
+		file_rut_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rut_proto_rawDesc), len(file_rut_proto_rawDesc)))
+	})
+	return file_rut_proto_rawDescData
+}
+
+var file_rut_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rut_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_rut_proto_goTypes = []any{
+	(Kind)(0),                     // 0: rut.v1.Kind
+	(Style)(0),                    // 1: rut.v1.Style
+	(*ValidateRequest)(nil),       // 2: rut.v1.ValidateRequest
+	(*ValidateResponse)(nil),      // 3: rut.v1.ValidateResponse
+	(*ValidateBatchRequest)(nil),  // 4: rut.v1.ValidateBatchRequest
+	(*Report)(nil),                // 5: rut.v1.Report
+	(*ValidateBatchResponse)(nil), // 6: rut.v1.ValidateBatchResponse
+	(*GenerateRequest)(nil),       // 7: rut.v1.GenerateRequest
+	(*GenerateResponse)(nil),      // 8: rut.v1.GenerateResponse
+	(*FormatRequest)(nil),         // 9: rut.v1.FormatRequest
+	(*FormatResponse)(nil),        // 10: rut.v1.FormatResponse
+	(*Rut)(nil),                   // 11: rut.v1.Rut
+	nil,                           // 12: rut.v1.Report.ErrorsEntry
+}
+var file_rut_proto_depIdxs = []int32{
+	12, // 0: rut.v1.Report.errors:type_name -> rut.v1.Report.ErrorsEntry
+	3,  // 1: rut.v1.ValidateBatchResponse.results:type_name -> rut.v1.ValidateResponse
+	5,  // 2: rut.v1.ValidateBatchResponse.report:type_name -> rut.v1.Report
+	0,  // 3: rut.v1.GenerateRequest.kind:type_name -> rut.v1.Kind
+	1,  // 4: rut.v1.GenerateRequest.style:type_name -> rut.v1.Style
+	1,  // 5: rut.v1.FormatRequest.style:type_name -> rut.v1.Style
+	2,  // 6: rut.v1.RutService.Validate:input_type -> rut.v1.ValidateRequest
+	4,  // 7: rut.v1.RutService.ValidateBatch:input_type -> rut.v1.ValidateBatchRequest
+	7,  // 8: rut.v1.RutService.Generate:input_type -> rut.v1.GenerateRequest
+	9,  // 9: rut.v1.RutService.Format:input_type -> rut.v1.FormatRequest
+	3,  // 10: rut.v1.RutService.Validate:output_type -> rut.v1.ValidateResponse
+	6,  // 11: rut.v1.RutService.ValidateBatch:output_type -> rut.v1.ValidateBatchResponse
+	8,  // 12: rut.v1.RutService.Generate:output_type -> rut.v1.GenerateResponse
+	10, // 13: rut.v1.RutService.Format:output_type -> rut.v1.FormatResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_rut_proto_init() }
+func file_rut_proto_init() {
+	if File_rut_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rut_proto_rawDesc), len(file_rut_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rut_proto_goTypes,
+		DependencyIndexes: file_rut_proto_depIdxs,
+		EnumInfos:         file_rut_proto_enumTypes,
+		MessageInfos:      file_rut_proto_msgTypes,
+	}.Build()
+	File_rut_proto = out.File
+	file_rut_proto_goTypes = nil
+	file_rut_proto_depIdxs = nil
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: rut.proto
+
+package rutpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RutService_Validate_FullMethodName      = "/rut.v1.RutService/Validate"
+	RutService_ValidateBatch_FullMethodName = "/rut.v1.RutService/ValidateBatch"
+	RutService_Generate_FullMethodName      = "/rut.v1.RutService/Generate"
+	RutService_Format_FullMethodName        = "/rut.v1.RutService/Format"
+)
+
+// RutServiceClient is the client API for RutService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RutService validates, generates and formats 'Rol Único Tributario'
+type RutServiceClient interface {
+	// Validate validates a single rut, an invalid rut isn't an error
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// ValidateBatch validates every rut and summarizes the results
+	ValidateBatch(ctx context.Context, in *ValidateBatchRequest, opts ...grpc.CallOption) (*ValidateBatchResponse, error)
+	// Generate generates random valid ruts
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// Format rewrites a valid rut in the requested style
+	Format(ctx context.Context, in *FormatRequest, opts ...grpc.CallOption) (*FormatResponse, error)
+}
+
+type rutServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRutServiceClient(cc grpc.ClientConnInterface) RutServiceClient {
+	return &rutServiceClient{cc}
+}
+
+func (c *rutServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, RutService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rutServiceClient) ValidateBatch(ctx context.Context, in *ValidateBatchRequest, opts ...grpc.CallOption) (*ValidateBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateBatchResponse)
+	err := c.cc.Invoke(ctx, RutService_ValidateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rutServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, RutService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rutServiceClient) Format(ctx context.Context, in *FormatRequest, opts ...grpc.CallOption) (*FormatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FormatResponse)
+	err := c.cc.Invoke(ctx, RutService_Format_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RutServiceServer is the server API for RutService service.
+// All implementations must embed UnimplementedRutServiceServer
+// for forward compatibility.
+//
+// RutService validates, generates and formats 'Rol Único Tributario'
+type RutServiceServer interface {
+	// Validate validates a single rut, an invalid rut isn't an error
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// ValidateBatch validates every rut and summarizes the results
+	ValidateBatch(context.Context, *ValidateBatchRequest) (*ValidateBatchResponse, error)
+	// Generate generates random valid ruts
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// Format rewrites a valid rut in the requested style
+	Format(context.Context, *FormatRequest) (*FormatResponse, error)
+	mustEmbedUnimplementedRutServiceServer()
+}
+
+// UnimplementedRutServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRutServiceServer struct{}
+
+func (UnimplementedRutServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedRutServiceServer) ValidateBatch(context.Context, *ValidateBatchRequest) (*ValidateBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateBatch not implemented")
+}
+func (UnimplementedRutServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedRutServiceServer) Format(context.Context, *FormatRequest) (*FormatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Format not implemented")
+}
+func (UnimplementedRutServiceServer) mustEmbedUnimplementedRutServiceServer() {}
+func (UnimplementedRutServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeRutServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RutServiceServer will
+// result in compilation errors.
+type UnsafeRutServiceServer interface {
+	mustEmbedUnimplementedRutServiceServer()
+}
+
+func RegisterRutServiceServer(s grpc.ServiceRegistrar, srv RutServiceServer) {
+	// If the following call panics, it indicates UnimplementedRutServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RutService_ServiceDesc, srv)
+}
+
+func _RutService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RutServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RutService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RutServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RutService_ValidateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RutServiceServer).ValidateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RutService_ValidateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RutServiceServer).ValidateBatch(ctx, req.(*ValidateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RutService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RutServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RutService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RutServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RutService_Format_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FormatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RutServiceServer).Format(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RutService_Format_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RutServiceServer).Format(ctx, req.(*FormatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RutService_ServiceDesc is the grpc.ServiceDesc for RutService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RutService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rut.v1.RutService",
+	HandlerType: (*RutServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _RutService_Validate_Handler,
+		},
+		{
+			MethodName: "ValidateBatch",
+			Handler:    _RutService_ValidateBatch_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _RutService_Generate_Handler,
+		},
+		{
+			MethodName: "Format",
+			Handler:    _RutService_Format_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rut.proto",
+}
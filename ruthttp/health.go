@@ -0,0 +1,118 @@
+package ruthttp
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// DefaultReadyTimeout bounds the checks of a /readyz when
+// HealthOptions.Timeout is unset
+const DefaultReadyTimeout = 5 * time.Second
+
+// HealthOptions configures the Health probes
+type HealthOptions struct {
+	// Version is answered by /version, defaults to the main module
+	// version of the build
+	Version string
+
+	// Checks are run concurrently by /readyz, keyed by name, eg. the
+	// reachability of a rutverify.Verifier
+	Checks map[string]func(ctx context.Context) error
+
+	// Timeout bounds the checks of a /readyz
+	Timeout time.Duration
+}
+
+// Health serves the probes of a service
+//
+//	GET /healthz	200 while the process serves requests
+//	GET /readyz	200 when every check passes, 503 otherwise
+//	GET /version	the build version
+type Health struct {
+	opts HealthOptions
+	mux  *http.ServeMux
+}
+
+// Status is the response of /healthz and /readyz, Checks holds "ok" or
+// the error of every failed check
+type Status struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Version is the response of /version
+type Version struct {
+	Version  string `json:"version"`
+	Revision string `json:"revision,omitempty"`
+	Go       string `json:"go"`
+}
+
+// NewHealth returns a Health with its routes registered
+func NewHealth(opts HealthOptions) *Health {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultReadyTimeout
+	}
+
+	h := &Health{opts: opts, mux: http.NewServeMux()}
+	h.mux.Handle("/healthz", method(http.MethodGet, h.healthz))
+	h.mux.Handle("/readyz", method(http.MethodGet, h.readyz))
+	h.mux.Handle("/version", method(http.MethodGet, h.version))
+	return h
+}
+
+func (h *Health) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Health) healthz(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, Status{Status: "ok"})
+}
+
+func (h *Health) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+	defer cancel()
+
+	resp := Status{Status: "ok", Checks: make(map[string]string, len(h.opts.Checks))}
+	code := http.StatusOK
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range h.opts.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := "ok"
+			if err := check(ctx); err != nil {
+				result = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[name] = result
+			if result != "ok" {
+				resp.Status, code = "unavailable", http.StatusServiceUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+	WriteJSON(w, code, resp)
+}
+
+func (h *Health) version(w http.ResponseWriter, r *http.Request) {
+	v := Version{Version: h.opts.Version, Go: runtime.Version()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v.Version == "" {
+			v.Version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				v.Revision = s.Value
+			}
+		}
+	}
+	WriteJSON(w, http.StatusOK, v)
+}
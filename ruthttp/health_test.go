@@ -0,0 +1,71 @@
+package ruthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func probe(t *testing.T, h *Health, method, target string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatal(err, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestHealth(t *testing.T) {
+	down := errors.New("verifier unreachable")
+	var verifierErr error
+	h := NewHealth(HealthOptions{
+		Version: "v1.2.3",
+		Timeout: 10 * time.Millisecond,
+		Checks: map[string]func(context.Context) error{
+			"verifier": func(ctx context.Context) error { return verifierErr },
+			"slow": func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+		},
+	})
+
+	var st Status
+	if code := probe(t, h, "GET", "/healthz", &st); code != http.StatusOK || st.Status != "ok" {
+		t.Error("unexpected /healthz", code, st)
+	}
+
+	st = Status{}
+	if code := probe(t, h, "GET", "/readyz", &st); code != http.StatusOK || st.Status != "ok" || st.Checks["verifier"] != "ok" || st.Checks["slow"] != "ok" {
+		t.Error("unexpected /readyz", code, st)
+	}
+
+	verifierErr = down
+	st = Status{}
+	if code := probe(t, h, "GET", "/readyz", &st); code != http.StatusServiceUnavailable || st.Status != "unavailable" || st.Checks["verifier"] != down.Error() {
+		t.Error("unexpected /readyz", code, st)
+	}
+
+	var v Version
+	if code := probe(t, h, "GET", "/version", &v); code != http.StatusOK || v.Version != "v1.2.3" || v.Go == "" {
+		t.Error("unexpected /version", code, v)
+	}
+
+	if code := probe(t, h, "POST", "/healthz", nil); code != http.StatusMethodNotAllowed {
+		t.Error("expected 405, got", code)
+	}
+}
+
+func TestHealthNoChecks(t *testing.T) {
+	var st Status
+	if code := probe(t, NewHealth(HealthOptions{}), "GET", "/readyz", &st); code != http.StatusOK || st.Status != "ok" || len(st.Checks) != 0 {
+		t.Error("unexpected /readyz", code, st)
+	}
+}
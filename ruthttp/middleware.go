@@ -0,0 +1,54 @@
+package ruthttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alvarolm/rut"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying r
+func NewContext(ctx context.Context, r rut.Rut) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the rut stored by RequireValidRUT
+func FromContext(ctx context.Context) (r rut.Rut, ok bool) {
+	r, ok = ctx.Value(contextKey{}).(rut.Rut)
+	return
+}
+
+// RequireValidRUT returns a middleware validating the rut found in the
+// param path value (as set by http.ServeMux patterns) or, when empty,
+// the param query value. valid ruts are stored normalized in the request
+// context, see FromContext, invalid ones are answered with 422 and an
+// ErrorResponse carrying the error code
+func RequireValidRUT(param string) func(http.Handler) http.Handler {
+	return RequireValidRUTFrom(func(r *http.Request) string {
+		if v := r.PathValue(param); v != "" {
+			return v
+		}
+		return r.URL.Query().Get(param)
+	})
+}
+
+// RequireValidRUTFrom is RequireValidRUT reading the rut with extract,
+// routers with their own path parameters use it, e.g. with chi
+//
+//	ruthttp.RequireValidRUTFrom(func(r *http.Request) string {
+//		return chi.URLParam(r, "rut")
+//	})
+func RequireValidRUTFrom(extract func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res := rut.Check(extract(r))
+			if res.Err != nil {
+				WriteError(w, http.StatusUnprocessableEntity, res.Err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), res.Rut)))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package ruthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestRequireValidRUT(t *testing.T) {
+	var got rut.Rut
+	h := RequireValidRUT("rut")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		if got, ok = FromContext(r.Context()); !ok {
+			t.Error("no rut in context")
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/?rut=11.111.111-1", nil))
+	if rec.Code != http.StatusOK || got != "11111111-1" {
+		t.Error("unexpected response", rec.Code, got)
+	}
+
+	req := httptest.NewRequest("GET", "/clientes/13117182-k", nil)
+	req.SetPathValue("rut", "13117182-k")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || got != "13117182-K" {
+		t.Error("unexpected response", rec.Code, got)
+	}
+
+	for target, code := range map[string]string{
+		"/?rut=12345678-0": "invalid_dv",
+		"/":                "min_length",
+	} {
+		var e ErrorResponse
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Error(target, "unexpected status", rec.Code)
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil || e.Error.Code != code {
+			t.Error(target, "expected", code, "got", e.Error.Code, err)
+		}
+	}
+}
+
+func TestRequireValidRUTFrom(t *testing.T) {
+	h := RequireValidRUTFrom(func(r *http.Request) string {
+		return r.Header.Get("X-Rut")
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Rut", "11111111-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Error("unexpected status", rec.Code)
+	}
+}
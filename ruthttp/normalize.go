@@ -0,0 +1,141 @@
+package ruthttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/alvarolm/rut"
+)
+
+// NormalizeFields returns a middleware validating the ruts of the named
+// fields and rewriting them normalized before the handler runs. it looks
+// up the query and form values and the top level members of JSON object
+// bodies, the fields absent, null or empty are ignored. requests with
+// invalid ruts, or JSON members that aren't strings, are answered with 422
+// and a CodeInvalidFields ErrorResponse listing every invalid field. JSON
+// bodies are bounded by DefaultMaxBodyBytes and re-encoded when a field
+// is rewritten
+func NormalizeFields(fields ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var invalid []FieldError
+			check := func(field, value string) string {
+				if value == "" {
+					return value
+				}
+				res := rut.Check(value)
+				if res.Err != nil {
+					invalid = append(invalid, FieldError{Field: field, Code: rut.Code(res.Err), Message: res.Err.Error()})
+					return value
+				}
+				return string(res.Rut)
+			}
+			notString := func(field string) {
+				invalid = append(invalid, FieldError{Field: field, Code: CodeBadRequest, Message: "expected a string"})
+			}
+
+			mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediatype == "application/json" && r.Body != nil {
+				if err := normalizeJSON(w, r, fields, check, notString); err != nil {
+					WriteError(w, http.StatusBadRequest, err)
+					return
+				}
+				normalizeQuery(r, fields, check)
+			} else {
+				if err := r.ParseForm(); err != nil {
+					WriteError(w, http.StatusBadRequest, badRequest(err.Error()))
+					return
+				}
+				for _, field := range fields {
+					for i, v := range r.Form[field] {
+						r.Form[field][i] = check(field, v)
+					}
+					// r.Form repeats the r.PostForm values, already checked
+					for i, v := range r.PostForm[field] {
+						if normalized, err := rut.Parse(v); err == nil {
+							r.PostForm[field][i] = string(normalized)
+						}
+					}
+				}
+			}
+
+			if invalid != nil {
+				WriteJSON(w, http.StatusUnprocessableEntity, ErrorResponse{ErrorBody{
+					Code:    CodeInvalidFields,
+					Message: "invalid rut fields",
+					Fields:  invalid,
+				}})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// normalizeQuery rewrites the query values of the fields with check, the
+// form branch does it through r.Form instead
+func normalizeQuery(r *http.Request, fields []string, check func(field, value string) string) {
+	query := r.URL.Query()
+	var changed bool
+	for _, field := range fields {
+		for i, v := range query[field] {
+			if normalized := check(field, v); normalized != v {
+				query[field][i] = normalized
+				changed = true
+			}
+		}
+	}
+	if changed {
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+// normalizeJSON rewrites the string fields of the JSON object body of r
+// with check, the fields of other types are passed to notString. other
+// bodies are left as read
+func normalizeJSON(w http.ResponseWriter, r *http.Request, fields []string, check func(field, value string) string, notString func(field string)) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, DefaultMaxBodyBytes))
+	r.Body.Close()
+	if err != nil {
+		return badRequest("invalid request body: " + err.Error())
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return badRequest("invalid request body: " + err.Error())
+	}
+
+	var changed bool
+	for _, field := range fields {
+		raw, ok := object[field]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		var v string
+		if json.Unmarshal(raw, &v) != nil {
+			notString(field)
+			continue
+		}
+		if normalized := check(field, v); normalized != v {
+			object[field], _ = json.Marshal(normalized)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if body, err = json.Marshal(object); err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return nil
+}
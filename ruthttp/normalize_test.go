@@ -0,0 +1,88 @@
+package ruthttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFields(t *testing.T) {
+	var got map[string]string
+	h := NormalizeFields("rut", "client")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = map[string]string{"rut": r.FormValue("rut"), "client": r.PostFormValue("client"), "other": r.FormValue("other")}
+		if r.Header.Get("Content-Type") == "application/json" {
+			body, _ := io.ReadAll(r.Body)
+			got = map[string]string{"body": string(body)}
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/?rut=11.111.111-1", strings.NewReader("client=13117182-k&other=1.2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || got["rut"] != "11111111-1" || got["client"] != "13117182-K" || got["other"] != "1.2" {
+		t.Error("unexpected request", rec.Code, got)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"rut": "12.345.678-5", "n": 1.50, "client": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(rec, req)
+	if expected := `{"client":"","n":1.50,"rut":"12345678-5"}`; rec.Code != http.StatusOK || got["body"] != expected {
+		t.Error("unexpected request", rec.Code, got)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/?rut=12345678-0", strings.NewReader("client=1-9"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(rec, req)
+	var e ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil || rec.Code != http.StatusUnprocessableEntity {
+		t.Fatal("unexpected response", rec.Code, rec.Body.String(), err)
+	}
+	expected := []FieldError{
+		{Field: "rut", Code: "invalid_dv", Message: "invalid 'digito verificador'"},
+		{Field: "client", Code: "min_length", Message: "length less than expected"},
+	}
+	if e.Error.Code != CodeInvalidFields || len(e.Error.Fields) != 2 || e.Error.Fields[0] != expected[0] || e.Error.Fields[1] != expected[1] {
+		t.Error("unexpected error", e)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/?rut=11.111.111-1", strings.NewReader(`{"client": null}`))
+	req.Header.Set("Content-Type", "application/json")
+	var query string
+	NormalizeFields("rut", "client")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("rut")
+	})).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || query != "11111111-1" {
+		t.Error("unexpected request", rec.Code, query)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/?rut=12345678-0", strings.NewReader(`{"client": 13117182}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(rec, req)
+	e = ErrorResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil || rec.Code != http.StatusUnprocessableEntity {
+		t.Fatal("unexpected response", rec.Code, rec.Body.String(), err)
+	}
+	expected = []FieldError{
+		{Field: "client", Code: CodeBadRequest, Message: "expected a string"},
+		{Field: "rut", Code: "invalid_dv", Message: "invalid 'digito verificador'"},
+	}
+	if len(e.Error.Fields) != 2 || e.Error.Fields[0] != expected[0] || e.Error.Fields[1] != expected[1] {
+		t.Error("unexpected error", e)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"rut": `))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Error("expected", http.StatusBadRequest, "got", rec.Code)
+	}
+}
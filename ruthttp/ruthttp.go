@@ -0,0 +1,380 @@
+/*
+Package ruthttp serves rut validation, generation and formatting over HTTP
+with JSON request and response bodies
+
+	POST /validate	{"rut": "..."} or {"ruts": ["...", ...]}
+	GET  /generate	?n=10&kind=person&min=&max=&style=dotted&unique=true
+	POST /format	{"rut": "...", "style": "dotted"} or {"ruts": [...], "style": "..."}
+
+failed requests are answered with an ErrorResponse.
+RequireValidRUT validates the ruts of other services' routes
+*/
+package ruthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/alvarolm/rut"
+)
+
+// Defaults of the Options fields left unset
+const (
+	DefaultMaxBatch     = 1000
+	DefaultMaxGenerate  = 10000
+	DefaultMaxBodyBytes = 1 << 20
+)
+
+// Codes of the ErrorResponse not produced by the rut package
+const (
+	CodeBadRequest       = "bad_request"
+	CodeBatchTooLarge    = "batch_too_large"
+	CodeExhausted        = "exhausted"
+	CodeInternal         = "internal"
+	CodeInvalidFields    = "invalid_fields"
+	CodeMethodNotAllowed = "method_not_allowed"
+)
+
+// Options configures the Server
+type Options struct {
+	// MaxBatch bounds the ruts of a single /validate or /format request
+	MaxBatch int
+
+	// MaxGenerate bounds the n parameter of /generate
+	MaxGenerate int
+
+	// MaxBodyBytes bounds the size of the request bodies
+	MaxBodyBytes int64
+
+	// Workers is passed to rut.ValidateBatch
+	Workers int
+
+	// Observer, if set, receives every validation and generation served
+	Observer Observer
+}
+
+// Observer receives the outcome of the served requests, rutprom.Metrics
+// implements it
+type Observer interface {
+	ObserveResult(res rut.Result)
+	ObserveGenerated(n int)
+}
+
+// Server is the http.Handler of the service
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// NewServer returns a Server with its routes registered
+func NewServer(opts Options) *Server {
+	if opts.MaxBatch <= 0 {
+		opts.MaxBatch = DefaultMaxBatch
+	}
+	if opts.MaxGenerate <= 0 {
+		opts.MaxGenerate = DefaultMaxGenerate
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	s.mux.Handle("/validate", method(http.MethodPost, s.validate))
+	s.mux.Handle("/generate", method(http.MethodGet, s.generate))
+	s.mux.Handle("/format", method(http.MethodPost, s.format))
+	return s
+}
+
+func (s *Server) observe(res rut.Result) {
+	if s.opts.Observer != nil {
+		s.opts.Observer.ObserveResult(res)
+	}
+}
+
+// method rejects the requests not using m with 405
+func method(m string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != m {
+			w.Header().Set("Allow", m)
+			WriteError(w, http.StatusMethodNotAllowed, rut.NewError(CodeMethodNotAllowed, r.Method+" not allowed, expected "+m))
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Request is the body of /validate and /format, either Rut or Ruts is set
+type Request struct {
+	Rut   string   `json:"rut,omitempty"`
+	Ruts  []string `json:"ruts,omitempty"`
+	Style string   `json:"style,omitempty"`
+}
+
+// Validation is the result of validating a single rut
+type Validation struct {
+	Input      string `json:"input"`
+	Valid      bool   `json:"valid"`
+	Normalized string `json:"normalized,omitempty"`
+	ExpectedDV string `json:"expected_dv,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewValidation converts a rut.Result
+func NewValidation(res rut.Result) (v Validation) {
+	v = Validation{
+		Input:      res.Input,
+		Valid:      res.Valid(),
+		Normalized: string(res.Rut),
+		Code:       rut.Code(res.Err),
+	}
+	if res.ExpectedDV != 0 {
+		v.ExpectedDV = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		v.Error = res.Err.Error()
+	}
+	return
+}
+
+// BatchValidation is the response of /validate with Ruts set
+type BatchValidation struct {
+	Results []Validation `json:"results"`
+	Report  *rut.Report  `json:"report"`
+}
+
+// Formatted is the result of formatting a single rut
+type Formatted struct {
+	Input     string `json:"input"`
+	Formatted string `json:"formatted,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchFormatted is the response of /format with Ruts set
+type BatchFormatted struct {
+	Results []Formatted `json:"results"`
+}
+
+// Generated is the response of /generate
+type Generated struct {
+	Ruts []string `json:"ruts"`
+}
+
+// ErrorResponse is the body of every failed request
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes why a request failed
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// Fields are the invalid fields of a CodeInvalidFields error
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is a request field holding an invalid rut
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewErrorResponse returns the ErrorResponse of err with rut.Code(err)
+func NewErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{ErrorBody{Code: rut.Code(err), Message: err.Error()}}
+}
+
+// WriteError writes err as an ErrorResponse with the given status and rut.Code(err)
+func WriteError(w http.ResponseWriter, status int, err error) {
+	WriteJSON(w, status, NewErrorResponse(err))
+}
+
+// WriteJSON writes v as the JSON body of the response
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+func badRequest(msg string) error {
+	return rut.NewError(CodeBadRequest, msg)
+}
+
+func (s *Server) decode(w http.ResponseWriter, r *http.Request) (req Request, err error) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.opts.MaxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&req); err != nil {
+		err = badRequest("invalid request body: " + err.Error())
+		return
+	}
+	switch {
+	case req.Rut == "" && req.Ruts == nil:
+		err = badRequest("rut or ruts is required")
+	case req.Rut != "" && req.Ruts != nil:
+		err = badRequest("rut and ruts are mutually exclusive")
+	case len(req.Ruts) > s.opts.MaxBatch:
+		err = rut.NewError(CodeBatchTooLarge, fmt.Sprintf("at most %d ruts per request", s.opts.MaxBatch))
+	}
+	return
+}
+
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	req, err := s.decode(w, r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Ruts == nil {
+		res := rut.Check(req.Rut)
+		s.observe(res)
+		WriteJSON(w, http.StatusOK, NewValidation(res))
+		return
+	}
+
+	results := rut.ValidateBatch(r.Context(), req.Ruts, s.opts.Workers)
+	if err := r.Context().Err(); err != nil {
+		return
+	}
+	resp := BatchValidation{Results: make([]Validation, len(results)), Report: rut.NewReport(results...)}
+	for i, res := range results {
+		s.observe(res)
+		resp.Results[i] = NewValidation(res)
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) format(w http.ResponseWriter, r *http.Request) {
+	req, err := s.decode(w, r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	opts := rut.FormatOptions{Style: rut.StyleDotted}
+	if req.Style != "" {
+		if opts.Style, err = rut.ParseStyle(req.Style); err != nil {
+			WriteError(w, http.StatusBadRequest, badRequest(err.Error()))
+			return
+		}
+	}
+
+	if req.Ruts == nil {
+		f := formatOne(req.Rut, opts)
+		if f.Code != "" {
+			WriteJSON(w, http.StatusUnprocessableEntity, ErrorResponse{ErrorBody{Code: f.Code, Message: f.Error}})
+			return
+		}
+		WriteJSON(w, http.StatusOK, f)
+		return
+	}
+
+	resp := BatchFormatted{Results: make([]Formatted, len(req.Ruts))}
+	for i, input := range req.Ruts {
+		resp.Results[i] = formatOne(input, opts)
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+func formatOne(input string, opts rut.FormatOptions) (f Formatted) {
+	f.Input = input
+	r := rut.Rut(input)
+	s, err := r.Format(opts)
+	if err != nil {
+		f.Code, f.Error = rut.Code(err), err.Error()
+		return
+	}
+	f.Formatted = s
+	return
+}
+
+func (s *Server) generate(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var (
+		opts  rut.GenerateOptions
+		style = rut.StyleDotted
+		n     = 1
+		err   error
+	)
+	intParam := func(name string, dst *int) {
+		if v := q.Get(name); v != "" && err == nil {
+			if *dst, err = strconv.Atoi(v); err != nil {
+				err = badRequest(fmt.Sprintf("invalid %s %q", name, v))
+			}
+		}
+	}
+	intParam("n", &n)
+	intParam("min", &opts.Min)
+	intParam("max", &opts.Max)
+	if v := q.Get("kind"); v != "" && err == nil {
+		if opts.Kind, err = rut.ParseKind(v); err != nil {
+			err = badRequest(err.Error())
+		}
+	}
+	if v := q.Get("style"); v != "" && err == nil {
+		if style, err = rut.ParseStyle(v); err != nil {
+			err = badRequest(err.Error())
+		}
+	}
+	if v := q.Get("unique"); v != "" && err == nil {
+		if opts.Unique, err = strconv.ParseBool(v); err != nil {
+			err = badRequest(fmt.Sprintf("invalid unique %q", v))
+		}
+	}
+	if err == nil && (opts.Min != 0 || opts.Max != 0) {
+		// the ruts of the range bounds must be valid, so every generated one is
+		for _, body := range []int{opts.Min, opts.Max - 1} {
+			if _, ferr := rut.FromBody(body); ferr != nil && err == nil {
+				err = badRequest(fmt.Sprintf("invalid range [%d, %d): %v", opts.Min, opts.Max, ferr))
+			}
+		}
+	}
+	if err == nil && (n < 1 || n > s.opts.MaxGenerate) {
+		err = rut.NewError(CodeBatchTooLarge, fmt.Sprintf("n must be between 1 and %d", s.opts.MaxGenerate))
+	}
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// a generated rut failing to format is a bug, not a bad request
+	var formatErr error
+	resp := Generated{Ruts: make([]string, 0, n)}
+	opts.Each = func(g rut.Rut) error {
+		f, err := g.Format(rut.FormatOptions{Style: style})
+		if err != nil {
+			formatErr = err
+			return err
+		}
+		resp.Ruts = append(resp.Ruts, f)
+		return nil
+	}
+	err = rut.GenerateN(r.Context(), n, opts)
+	if s.opts.Observer != nil {
+		s.opts.Observer.ObserveGenerated(len(resp.Ruts))
+	}
+	if err != nil {
+		switch {
+		case r.Context().Err() != nil:
+		case formatErr != nil:
+			WriteError(w, http.StatusInternalServerError, rut.NewError(CodeInternal, formatErr.Error()))
+		case errors.Is(err, rut.ErrExhausted):
+			WriteError(w, http.StatusUnprocessableEntity, rut.NewError(CodeExhausted, err.Error()))
+		default:
+			WriteError(w, http.StatusBadRequest, badRequest(err.Error()))
+		}
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,151 @@
+package ruthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func do(t *testing.T, s *Server, method, target, body string, v any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatal(err, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestValidate(t *testing.T) {
+	s := NewServer(Options{})
+
+	var v Validation
+	if code := do(t, s, "POST", "/validate", `{"rut":"11.111.111-1"}`, &v); code != http.StatusOK {
+		t.Fatal("unexpected status", code)
+	}
+	if !v.Valid || v.Normalized != "11111111-1" {
+		t.Error("unexpected validation", v)
+	}
+
+	var batch BatchValidation
+	if code := do(t, s, "POST", "/validate", `{"ruts":["11111111-1","12345678-0","x"]}`, &batch); code != http.StatusOK {
+		t.Fatal("unexpected status", code)
+	}
+	if len(batch.Results) != 3 || batch.Report.Valid != 1 || batch.Report.Invalid != 2 {
+		t.Error("unexpected batch", batch)
+	}
+	if r := batch.Results[1]; r.Code != "invalid_dv" || r.ExpectedDV != "5" {
+		t.Error("unexpected result", r)
+	}
+}
+
+func TestValidateBadRequest(t *testing.T) {
+	s := NewServer(Options{MaxBatch: 1})
+	for body, code := range map[string]string{
+		`{}`:                    CodeBadRequest,
+		`{"rut":"1","ruts":[]}`: CodeBadRequest,
+		`{"nid":"1"}`:           CodeBadRequest,
+		`not json`:              CodeBadRequest,
+		`{"ruts":["1","2"]}`:    CodeBatchTooLarge,
+	} {
+		var e ErrorResponse
+		if status := do(t, s, "POST", "/validate", body, &e); status != http.StatusBadRequest {
+			t.Error(body, "unexpected status", status)
+		}
+		if e.Error.Code != code {
+			t.Error(body, "expected", code, "got", e.Error.Code)
+		}
+	}
+
+	if status := do(t, s, "GET", "/validate", "", nil); status != http.StatusMethodNotAllowed {
+		t.Error("unexpected status", status)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	s := NewServer(Options{})
+
+	var f Formatted
+	if code := do(t, s, "POST", "/format", `{"rut":"11.111.111-1","style":"canonical"}`, &f); code != http.StatusOK {
+		t.Fatal("unexpected status", code)
+	}
+	if f.Formatted != "11111111-1" {
+		t.Error("unexpected formatted", f)
+	}
+
+	var e ErrorResponse
+	if code := do(t, s, "POST", "/format", `{"rut":"12345678-0"}`, &e); code != http.StatusUnprocessableEntity {
+		t.Error("unexpected status", code)
+	}
+	if e.Error.Code != "invalid_dv" {
+		t.Error("unexpected error", e)
+	}
+
+	var batch BatchFormatted
+	if code := do(t, s, "POST", "/format", `{"ruts":["11111111-1","12345678-0"]}`, &batch); code != http.StatusOK {
+		t.Fatal("unexpected status", code)
+	}
+	if batch.Results[0].Formatted != "11.111.111-1" || batch.Results[1].Code != "invalid_dv" {
+		t.Error("unexpected batch", batch)
+	}
+
+	if code := do(t, s, "POST", "/format", `{"rut":"11111111-1","style":"nope"}`, &e); code != http.StatusBadRequest || e.Error.Code != CodeBadRequest {
+		t.Error("unexpected response", code, e)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := NewServer(Options{MaxGenerate: 100})
+
+	var g Generated
+	if code := do(t, s, "GET", "/generate?n=20&kind=company&style=canonical&unique=true", "", &g); code != http.StatusOK {
+		t.Fatal("unexpected status", code)
+	}
+	if len(g.Ruts) != 20 {
+		t.Fatal("expected 20 ruts, got", len(g.Ruts))
+	}
+	for _, r := range g.Ruts {
+		if strings.Contains(r, ".") || r < "50000000" {
+			t.Error("unexpected rut", r)
+		}
+	}
+
+	var e ErrorResponse
+	for _, q := range []string{"n=101", "n=0", "n=x", "kind=x", "min=10&max=5", "min=-9223372036854775808&max=9223372036854775807", "min=1000000000&max=1000000010", "style=x", "unique=x"} {
+		if code := do(t, s, "GET", "/generate?"+q, "", &e); code != http.StatusBadRequest {
+			t.Error(q, "unexpected status", code)
+		}
+	}
+
+	if code := do(t, s, "GET", "/generate?n=20&min=1000000&max=1000010&unique=true", "", &e); code != http.StatusUnprocessableEntity || e.Error.Code != CodeExhausted {
+		t.Error("unexpected response", code, e)
+	}
+}
+
+type observer struct {
+	results   []string
+	generated int
+}
+
+func (o *observer) ObserveResult(res rut.Result) { o.results = append(o.results, rut.Code(res.Err)) }
+func (o *observer) ObserveGenerated(n int)       { o.generated += n }
+
+func TestObserver(t *testing.T) {
+	o := &observer{}
+	s := NewServer(Options{Observer: o})
+
+	do(t, s, "POST", "/validate", `{"rut":"11111111-1"}`, nil)
+	do(t, s, "POST", "/validate", `{"ruts":["12345678-0","x"]}`, nil)
+	do(t, s, "GET", "/generate?n=3", "", nil)
+
+	if len(o.results) != 3 || o.results[0] != "" || o.generated != 3 {
+		t.Error("unexpected observations", o.results, o.generated)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Package rutjsonl validates a 'Rol Único Tributario' field of JSON Lines
+streams, one object at a time
+*/
+package rutjsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrNoField   = rut.NewError("no_field", "object has no rut field")
+	ErrNotString = rut.NewError("not_string", "rut field is not a string")
+	ErrNotObject = errors.New("line is not a JSON object")
+)
+
+// DefaultKey is the Processor.Key used when unset
+const DefaultKey = "rut_validation"
+
+// Validation is the result added to every object
+type Validation struct {
+	Valid      bool   `json:"valid"`
+	ExpectedDV string `json:"expected_dv,omitempty"`
+	Normalized string `json:"normalized,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Processor augments JSON Lines objects with the validation of one of their fields
+type Processor struct {
+	// Field is the dot separated path of the rut field, eg. "customer.rut"
+	Field string
+
+	// Key is the top level key the Validation is stored under,
+	// defaults to DefaultKey
+	Key string
+}
+
+// Process reads one JSON object per line from r and writes it to w with
+// the Validation of Field added under Key. blank lines are skipped,
+// objects are streamed, the input is never held in memory,
+// the report accounts every object
+func (p *Processor) Process(w io.Writer, r io.Reader) (report rut.Report, err error) {
+	key := p.Key
+	if key == "" {
+		key = DefaultKey
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	return p.scan(r, func(_ int, obj map[string]any, res rut.Result) error {
+		obj[key] = annotate(res)
+		return enc.Encode(obj)
+	})
+}
+
+// Each streams the validation of every object of r to fn, with its line
+// number, until fn returns an error
+func (p *Processor) Each(r io.Reader, fn func(line int, res rut.Result) error) (rut.Report, error) {
+	return p.scan(r, func(line int, _ map[string]any, res rut.Result) error {
+		return fn(line, res)
+	})
+}
+
+func (p *Processor) scan(r io.Reader, fn func(int, map[string]any, rut.Result) error) (report rut.Report, err error) {
+	path := strings.Split(p.Field, ".")
+	br := bufio.NewReader(r)
+
+	for n := 1; ; n++ {
+		var line []byte
+		line, err = br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		eof := err == io.EOF
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var obj map[string]any
+			dec := json.NewDecoder(bytes.NewReader(line))
+			// keeps numbers as they were written
+			dec.UseNumber()
+			if err = dec.Decode(&obj); err != nil || obj == nil {
+				if err == nil {
+					err = ErrNotObject
+				}
+				return report, fmt.Errorf("line %d: %w", n, err)
+			}
+
+			res := validate(lookup(obj, path))
+			report.Add(res)
+			if err = fn(n, obj, res); err != nil {
+				return
+			}
+		}
+
+		if eof {
+			return report, nil
+		}
+	}
+}
+
+// lookup returns the value at path, nil when missing
+func lookup(obj map[string]any, path []string) any {
+	var v any = obj
+	for _, k := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if v, ok = m[k]; !ok {
+			return nil
+		}
+	}
+	return v
+}
+
+func validate(v any) rut.Result {
+	switch s := v.(type) {
+	case nil:
+		return rut.Result{Err: ErrNoField}
+	case string:
+		return rut.Check(s)
+	default:
+		return rut.Result{Err: ErrNotString}
+	}
+}
+
+func annotate(res rut.Result) (v Validation) {
+	if res.ExpectedDV != 0 {
+		v.ExpectedDV = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		v.Error = res.Err.Error()
+		return
+	}
+	v.Valid = true
+	v.Normalized = string(res.Rut)
+	return
+}
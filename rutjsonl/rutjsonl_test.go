@@ -0,0 +1,63 @@
+package rutjsonl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestProcess(t *testing.T) {
+	in := strings.Join([]string{
+		`{"id":1,"customer":{"rut":"11.111.111-1"}}`,
+		``,
+		`{"id":2,"customer":{"rut":"12345678-0"}}`,
+		`{"id":3,"customer":{"rut":12345678}}`,
+		`{"id":4.50}`,
+	}, "\r\n")
+
+	var out bytes.Buffer
+	p := Processor{Field: "customer.rut"}
+	report, err := p.Process(&out, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Errors["not_string"] != 1 || report.Errors["no_field"] != 1 {
+		t.Error("unexpected report", report)
+	}
+
+	expected := strings.Join([]string{
+		`{"customer":{"rut":"11.111.111-1"},"id":1,"rut_validation":{"valid":true,"expected_dv":"1","normalized":"11111111-1"}}`,
+		`{"customer":{"rut":"12345678-0"},"id":2,"rut_validation":{"valid":false,"expected_dv":"5","error":"invalid 'digito verificador'"}}`,
+		`{"customer":{"rut":12345678},"id":3,"rut_validation":{"valid":false,"error":"rut field is not a string"}}`,
+		`{"id":4.50,"rut_validation":{"valid":false,"error":"object has no rut field"}}`,
+		``,
+	}, "\n")
+	if out.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestProcessInvalidLine(t *testing.T) {
+	p := Processor{Field: "rut"}
+	_, err := p.Process(&bytes.Buffer{}, strings.NewReader("{\"rut\":\"11111111-1\"}\n[1]\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Error("expected line 2 error, got", err)
+	}
+}
+
+func TestEach(t *testing.T) {
+	var lines []int
+	p := Processor{Field: "rut"}
+	report, err := p.Each(strings.NewReader("{\"rut\":\"11111111-1\"}\n\n{\"rut\":\"1\"}\n"), func(line int, res rut.Result) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[1] != 3 || report.Invalid != 1 {
+		t.Error("unexpected", lines, report)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Package rutotel instruments rut validation, generation and verification
+with OpenTelemetry traces and metrics
+
+	tel, err := rutotel.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+	srv := tel.Handler(ruthttp.NewServer(ruthttp.Options{Observer: tel}))
+	gs := grpc.NewServer(grpc.UnaryInterceptor(tel.UnaryServerInterceptor()))
+	verifier := tel.Verifier(&rutverify.SII{})
+
+the spans carry the rut.outcome, rut.error_code and rut.status attributes
+*/
+package rutotel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutgrpc"
+	"github.com/alvarolm/rut/ruthttp"
+	"github.com/alvarolm/rut/rutverify"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter
+const ScopeName = "github.com/alvarolm/rut/rutotel"
+
+// Outcome attribute values, the ones of rutprom
+const (
+	OutcomeValid   = "valid"
+	OutcomeInvalid = "invalid"
+	OutcomeError   = "error"
+)
+
+// Attribute keys
+const (
+	OutcomeKey   = attribute.Key("rut.outcome")
+	ErrorCodeKey = attribute.Key("rut.error_code")
+	StatusKey    = attribute.Key("rut.status")
+)
+
+// Telemetry is the rut instrumentation, it implements ruthttp.Observer
+type Telemetry struct {
+	tracer trace.Tracer
+
+	// rut.validations{rut.outcome, rut.error_code}
+	validations metric.Int64Counter
+
+	// rut.generated
+	generated metric.Int64Counter
+
+	// rut.verify.duration{rut.outcome, rut.status} in seconds
+	verifyDuration metric.Float64Histogram
+}
+
+// New returns the Telemetry of the providers, usually
+// otel.GetTracerProvider() and otel.GetMeterProvider()
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Telemetry, error) {
+	meter := mp.Meter(ScopeName)
+	t := &Telemetry{tracer: tp.Tracer(ScopeName)}
+
+	var err error
+	if t.validations, err = meter.Int64Counter("rut.validations",
+		metric.WithDescription("Validated ruts by outcome and error code.")); err != nil {
+		return nil, err
+	}
+	if t.generated, err = meter.Int64Counter("rut.generated",
+		metric.WithDescription("Generated ruts.")); err != nil {
+		return nil, err
+	}
+	if t.verifyDuration, err = meter.Float64Histogram("rut.verify.duration",
+		metric.WithDescription("Verifier lookup latency by outcome and status."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ObserveResult counts a validation
+func (t *Telemetry) ObserveResult(res rut.Result) {
+	t.validations.Add(context.Background(), 1, metric.WithAttributes(resultAttributes(res.Err)...))
+}
+
+// ObserveReport counts the validations summarized by the Report of a
+// batch processor
+func (t *Telemetry) ObserveReport(rp *rut.Report) {
+	ctx := context.Background()
+	if rp.Valid > 0 {
+		t.validations.Add(ctx, int64(rp.Valid), metric.WithAttributes(resultAttributes(nil)...))
+	}
+	for code, n := range rp.Errors {
+		t.validations.Add(ctx, int64(n), metric.WithAttributes(OutcomeKey.String(OutcomeInvalid), ErrorCodeKey.String(code)))
+	}
+}
+
+// ObserveGenerated counts n generated ruts
+func (t *Telemetry) ObserveGenerated(n int) {
+	t.generated.Add(context.Background(), int64(n))
+}
+
+// resultAttributes returns the outcome and error code of a validation
+func resultAttributes(err error) []attribute.KeyValue {
+	if err == nil {
+		return []attribute.KeyValue{OutcomeKey.String(OutcomeValid), ErrorCodeKey.String("")}
+	}
+	return []attribute.KeyValue{OutcomeKey.String(OutcomeInvalid), ErrorCodeKey.String(rut.Code(err))}
+}
+
+// Verifier returns v with a span per lookup and its latency observed, the
+// lookups of invalid ruts have the invalid outcome and other failures the
+// error one
+func (t *Telemetry) Verifier(v rutverify.Verifier) rutverify.Verifier {
+	return rutverify.VerifierFunc(func(ctx context.Context, r rut.Rut) (rutverify.Status, error) {
+		ctx, span := t.tracer.Start(ctx, "rutverify.Verify", trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		start := time.Now()
+		st, err := v.Verify(ctx, r)
+		outcome := OutcomeValid
+		if err != nil {
+			outcome = OutcomeError
+			var e *rut.Error
+			if errors.As(err, &e) {
+				outcome = OutcomeInvalid
+			}
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		t.verifyDuration.Record(ctx, time.Since(start).Seconds(),
+			metric.WithAttributes(OutcomeKey.String(outcome), StatusKey.String(st.String())))
+		span.SetAttributes(OutcomeKey.String(outcome), StatusKey.String(st.String()), ErrorCodeKey.String(rut.Code(err)))
+		return st, err
+	})
+}
+
+// Handler returns h with a span per request, continuing the trace of the
+// propagated headers. the ruthttp error code of the failed requests is
+// the rut.error_code attribute
+func (t *Telemetry) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := t.tracer.Start(ctx, r.Method+" "+r.URL.Path, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", sw.status))
+		if code := sw.errorCode(); code != "" {
+			span.SetAttributes(ErrorCodeKey.String(code))
+		}
+		switch {
+		case sw.status >= 500:
+			span.SetStatus(otelcodes.Error, strconv.Itoa(sw.status))
+			span.SetAttributes(OutcomeKey.String(OutcomeError))
+		case sw.status >= 400:
+			span.SetAttributes(OutcomeKey.String(OutcomeInvalid))
+		default:
+			span.SetAttributes(OutcomeKey.String(OutcomeValid))
+		}
+	})
+}
+
+// maxErrorBody bounds the bodies of the failed responses a statusWriter
+// keeps, an ErrorResponse is way shorter
+const maxErrorBody = 4096
+
+// statusWriter records the status code of a response and the body of the
+// failed ones, to read their ruthttp.ErrorResponse code
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status >= 400 && len(w.body) < maxErrorBody {
+		w.body = append(w.body, b[:min(len(b), maxErrorBody-len(w.body))]...)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// errorCode returns the code of the ErrorResponse body, "" if the response
+// didn't fail or isn't an ErrorResponse
+func (w *statusWriter) errorCode() string {
+	var e ruthttp.ErrorResponse
+	if w.body == nil || json.Unmarshal(w.body, &e) != nil {
+		return ""
+	}
+	return e.Error.Code
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor with a span per call,
+// the rut error code of the InvalidArgument statuses of rutgrpc is the
+// rut.error_code attribute
+func (t *Telemetry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := t.tracer.Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			span.SetAttributes(OutcomeKey.String(OutcomeValid))
+			return resp, nil
+		}
+
+		st := status.Convert(err)
+		span.SetAttributes(attribute.String("rpc.grpc.status_code", st.Code().String()))
+		for _, d := range st.Details() {
+			if ei, ok := d.(*errdetails.ErrorInfo); ok && ei.Domain == rutgrpc.ErrorDomain {
+				span.SetAttributes(OutcomeKey.String(OutcomeInvalid), ErrorCodeKey.String(ei.Reason))
+				return resp, err
+			}
+		}
+		span.SetAttributes(OutcomeKey.String(OutcomeError))
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, st.Message())
+		return resp, err
+	}
+}
@@ -0,0 +1,153 @@
+package rutotel
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutgrpc"
+	"github.com/alvarolm/rut/rutgrpc/rutpb"
+	"github.com/alvarolm/rut/ruthttp"
+	"github.com/alvarolm/rut/rutverify"
+)
+
+func newTelemetry(t *testing.T) (*Telemetry, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	sr := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	tel, err := New(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tel, sr, reader
+}
+
+// attr returns the value of key among the attributes of the span
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+// sums returns the int64 sums of the metric by outcome and error code
+func sums(t *testing.T, reader *sdkmetric.ManualReader, name string) map[[2]string]int64 {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[[2]string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				outcome, _ := dp.Attributes.Value(OutcomeKey)
+				code, _ := dp.Attributes.Value(ErrorCodeKey)
+				out[[2]string{outcome.AsString(), code.AsString()}] += dp.Value
+			}
+		}
+	}
+	return out
+}
+
+func TestMetrics(t *testing.T) {
+	tel, _, reader := newTelemetry(t)
+
+	tel.ObserveResult(rut.Check("11111111-1"))
+	tel.ObserveResult(rut.Check("12345678-0"))
+	tel.ObserveReport(rut.NewReport(rut.Check("12345678-5"), rut.Check("x"), rut.Check("12345678-1")))
+	tel.ObserveGenerated(5)
+
+	got := sums(t, reader, "rut.validations")
+	for labels, expected := range map[[2]string]int64{
+		{OutcomeValid, ""}:             2,
+		{OutcomeInvalid, "invalid_dv"}: 2,
+		{OutcomeInvalid, "min_length"}: 1,
+	} {
+		if got[labels] != expected {
+			t.Error(labels, "expected", expected, "got", got[labels])
+		}
+	}
+	if got := sums(t, reader, "rut.generated"); got[[2]string{}] != 5 {
+		t.Error("expected 5 generated, got", got)
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	tel, sr, _ := newTelemetry(t)
+	mock, _ := rutverify.NewMock(map[rut.Rut]rutverify.Status{"11111111-1": rutverify.StatusActive})
+	v := tel.Verifier(mock)
+
+	v.Verify(context.Background(), "11111111-1")
+	v.Verify(context.Background(), "12345678-0")
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatal("expected 2 spans, got", len(spans))
+	}
+	if spans[0].Name() != "rutverify.Verify" || attr(spans[0], OutcomeKey) != OutcomeValid || attr(spans[0], StatusKey) != "active" {
+		t.Error("unexpected span", spans[0].Name(), spans[0].Attributes())
+	}
+	if attr(spans[1], OutcomeKey) != OutcomeInvalid || attr(spans[1], ErrorCodeKey) != "invalid_dv" {
+		t.Error("unexpected span", spans[1].Attributes())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tel, sr, _ := newTelemetry(t)
+	h := tel.Handler(ruthttp.NewServer(ruthttp.Options{Observer: tel}))
+
+	for _, body := range []string{`{"rut": "11111111-1"}`, `{`} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/validate", strings.NewReader(body)))
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 2 || spans[0].Name() != "POST /validate" {
+		t.Fatal("unexpected spans", spans)
+	}
+	if attr(spans[0], OutcomeKey) != OutcomeValid || attr(spans[1], OutcomeKey) != OutcomeInvalid {
+		t.Error("unexpected outcomes", spans[0].Attributes(), spans[1].Attributes())
+	}
+	if attr(spans[1], "http.response.status_code") != "400" || attr(spans[1], ErrorCodeKey) != ruthttp.CodeBadRequest {
+		t.Error("unexpected status", spans[1].Attributes())
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tel, sr, _ := newTelemetry(t)
+	s := rutgrpc.NewServer(rutgrpc.Options{})
+	intercept := tel.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/rut.RutService/Format"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return s.Format(ctx, req.(*rutpb.FormatRequest))
+	}
+
+	intercept(context.Background(), &rutpb.FormatRequest{Rut: "11111111-1"}, info, handler)
+	intercept(context.Background(), &rutpb.FormatRequest{Rut: "12345678-0"}, info, handler)
+
+	spans := sr.Ended()
+	if len(spans) != 2 || spans[0].Name() != info.FullMethod {
+		t.Fatal("unexpected spans", spans)
+	}
+	if attr(spans[0], OutcomeKey) != OutcomeValid {
+		t.Error("unexpected span", spans[0].Attributes())
+	}
+	if attr(spans[1], OutcomeKey) != OutcomeInvalid || attr(spans[1], ErrorCodeKey) != "invalid_dv" || attr(spans[1], "rpc.grpc.status_code") != "InvalidArgument" {
+		t.Error("unexpected span", spans[1].Attributes())
+	}
+}
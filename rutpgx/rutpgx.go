@@ -0,0 +1,92 @@
+/*
+Package rutpgx stores rut.Rut values as their numeric 'cuerpo' in INTEGER
+or BIGINT columns with pgx v5, the 'digito verificador' is computed back
+when scanning
+
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		rutpgx.Register(conn.TypeMap())
+		return nil
+	}
+
+	_, err = conn.Exec(ctx, "INSERT INTO clients (rut) VALUES ($1)", rut.Rut("12.345.678-5"))
+	var r rut.Rut
+	err = conn.QueryRow(ctx, "SELECT rut FROM clients").Scan(&r)
+*/
+package rutpgx
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/alvarolm/rut"
+)
+
+// Codec wraps an integer codec, encoding rut.Rut values and scanning
+// into *rut.Rut, every other value is left to the wrapped codec
+type Codec struct {
+	pgtype.Codec
+}
+
+// Register replaces the int4 and int8 codecs of m with Codecs wrapping
+// them, rut.Rut parameters default to int4
+func Register(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{Name: "int4", OID: pgtype.Int4OID, Codec: Codec{pgtype.Int4Codec{}}})
+	m.RegisterType(&pgtype.Type{Name: "int8", OID: pgtype.Int8OID, Codec: Codec{pgtype.Int8Codec{}}})
+	m.RegisterDefaultPgType(rut.Rut(""), "int4")
+}
+
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(rut.Rut); ok {
+		if next := c.Codec.PlanEncode(m, oid, format, pgtype.Int8{}); next != nil {
+			return encodePlan{next}
+		}
+		return nil
+	}
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*rut.Rut); ok {
+		if next := c.Codec.PlanScan(m, oid, format, &pgtype.Int8{}); next != nil {
+			return scanPlan{next}
+		}
+		return nil
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+// encodePlan validates the rut and encodes its 'cuerpo' with the plan of pgtype.Int8
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	r := value.(rut.Rut)
+	body, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+	return p.next.Encode(pgtype.Int8{Int64: int64(body), Valid: true}, buf)
+}
+
+// scanPlan scans the 'cuerpo' with the plan of pgtype.Int8 and builds its rut
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	var body pgtype.Int8
+	if err := p.next.Scan(src, &body); err != nil {
+		return err
+	}
+	if !body.Valid {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	r, err := rut.FromBody(int(body.Int64))
+	if err != nil {
+		return err
+	}
+	*target.(*rut.Rut) = r
+	return nil
+}
@@ -0,0 +1,55 @@
+package rutpgx
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	for _, oid := range []uint32{pgtype.Int4OID, pgtype.Int8OID} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(oid, format, rut.Rut("13.117.182-k"), nil)
+			if err != nil {
+				t.Fatal(oid, format, err)
+			}
+
+			var n int64
+			if err := m.Scan(oid, format, buf, &n); err != nil || n != 13117182 {
+				t.Error(oid, format, "expected the 'cuerpo', got", n, err)
+			}
+
+			var r rut.Rut
+			if err := m.Scan(oid, format, buf, &r); err != nil || r != "13117182-K" {
+				t.Error(oid, format, "unexpected rut", r, err)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	if _, err := m.Encode(pgtype.Int4OID, pgtype.BinaryFormatCode, rut.Rut("12345678-0"), nil); err == nil {
+		t.Error("expected an error encoding an invalid rut")
+	}
+
+	var r rut.Rut
+	if err := m.Scan(pgtype.Int4OID, pgtype.TextFormatCode, nil, &r); err == nil {
+		t.Error("expected an error scanning NULL")
+	}
+	if err := m.Scan(pgtype.Int4OID, pgtype.TextFormatCode, []byte("12"), &r); err != rut.ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+
+	var p *rut.Rut
+	if err := m.Scan(pgtype.Int4OID, pgtype.TextFormatCode, nil, &p); err != nil || p != nil {
+		t.Error("expected a nil *rut.Rut for NULL, got", p, err)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Package rutprom exports Prometheus metrics of rut validation, generation
+and verification
+
+	m, err := rutprom.NewMetrics(prometheus.DefaultRegisterer)
+	srv := ruthttp.NewServer(ruthttp.Options{Observer: m})
+	verifier := m.Verifier(&rutverify.SII{})
+
+	report, err := processor.Process(w, r)
+	m.ObserveReport(&report)
+*/
+package rutprom
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutverify"
+)
+
+// Outcome label values
+const (
+	OutcomeValid   = "valid"
+	OutcomeInvalid = "invalid"
+	OutcomeError   = "error"
+)
+
+// Metrics are the rut collectors, it implements ruthttp.Observer
+type Metrics struct {
+	// Validations counts the validated ruts by outcome and error code,
+	// rut_validations_total{outcome="valid|invalid", code}
+	Validations *prometheus.CounterVec
+
+	// Generated counts the generated ruts, rut_generated_total
+	Generated prometheus.Counter
+
+	// VerifyDuration observes the Verifier lookups by outcome and status,
+	// rut_verify_duration_seconds{outcome="valid|invalid|error", status}
+	VerifyDuration *prometheus.HistogramVec
+}
+
+// NewMetrics returns Metrics registered with reg
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		Validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rut_validations_total",
+			Help: "Validated ruts by outcome and error code.",
+		}, []string{"outcome", "code"}),
+		Generated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rut_generated_total",
+			Help: "Generated ruts.",
+		}),
+		VerifyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rut_verify_duration_seconds",
+			Help:    "Verifier lookup latency by outcome and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"outcome", "status"}),
+	}
+	for _, c := range []prometheus.Collector{m.Validations, m.Generated, m.VerifyDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveResult counts a validation
+func (m *Metrics) ObserveResult(res rut.Result) {
+	if res.Err == nil {
+		m.Validations.WithLabelValues(OutcomeValid, "").Inc()
+		return
+	}
+	m.Validations.WithLabelValues(OutcomeInvalid, rut.Code(res.Err)).Inc()
+}
+
+// ObserveReport counts the validations summarized by the Report of a
+// batch processor
+func (m *Metrics) ObserveReport(rp *rut.Report) {
+	if rp.Valid > 0 {
+		m.Validations.WithLabelValues(OutcomeValid, "").Add(float64(rp.Valid))
+	}
+	for code, n := range rp.Errors {
+		m.Validations.WithLabelValues(OutcomeInvalid, code).Add(float64(n))
+	}
+}
+
+// ObserveGenerated counts n generated ruts
+func (m *Metrics) ObserveGenerated(n int) {
+	m.Generated.Add(float64(n))
+}
+
+// Verifier returns v observing the latency of every lookup, the lookups
+// of invalid ruts have the invalid outcome and other failures the error one
+func (m *Metrics) Verifier(v rutverify.Verifier) rutverify.Verifier {
+	return rutverify.VerifierFunc(func(ctx context.Context, r rut.Rut) (rutverify.Status, error) {
+		start := time.Now()
+		st, err := v.Verify(ctx, r)
+		outcome := OutcomeValid
+		if err != nil {
+			outcome = OutcomeError
+			var e *rut.Error
+			if errors.As(err, &e) {
+				outcome = OutcomeInvalid
+			}
+		}
+		m.VerifyDuration.WithLabelValues(outcome, st.String()).Observe(time.Since(start).Seconds())
+		return st, err
+	})
+}
@@ -0,0 +1,55 @@
+package rutprom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/rutverify"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewMetrics(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.ObserveResult(rut.Check("11111111-1"))
+	m.ObserveResult(rut.Check("12345678-0"))
+	m.ObserveReport(rut.NewReport(rut.Check("12345678-5"), rut.Check("x"), rut.Check("12345678-1")))
+	m.ObserveGenerated(5)
+
+	for labels, expected := range map[[2]string]float64{
+		{OutcomeValid, ""}:             2,
+		{OutcomeInvalid, "invalid_dv"}: 2,
+		{OutcomeInvalid, "min_length"}: 1,
+	} {
+		if got := testutil.ToFloat64(m.Validations.WithLabelValues(labels[0], labels[1])); got != expected {
+			t.Error(labels, "expected", expected, "got", got)
+		}
+	}
+	if got := testutil.ToFloat64(m.Generated); got != 5 {
+		t.Error("expected 5 generated, got", got)
+	}
+
+	if _, err := NewMetrics(reg); err == nil {
+		t.Error("expected a duplicate registration error")
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	m, _ := NewMetrics(prometheus.NewRegistry())
+	mock, _ := rutverify.NewMock(map[rut.Rut]rutverify.Status{"11111111-1": rutverify.StatusActive})
+	v := m.Verifier(mock)
+
+	v.Verify(context.Background(), "11111111-1")
+	v.Verify(context.Background(), "12345678-0")
+
+	if n := testutil.CollectAndCount(m.VerifyDuration); n != 2 {
+		t.Error("expected 2 series, got", n)
+	}
+}
@@ -0,0 +1,136 @@
+package rutset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrFalsePositiveRate = errors.New("false positive rate must be in (0, 1)")
+	ErrInvalidBloom      = errors.New("invalid bloom filter encoding")
+)
+
+// bloom encoding: magic, version, k, m, n and the bit words, little endian
+var bloommagic = [4]byte{'R', 'U', 'T', 'B'}
+
+const bloomversion = 1
+
+// maxBloomHashes bounds k, the rates NewBloom accepts need fewer hashes and
+// UnmarshalBinary rejects the encodings asking for more
+const maxBloomHashes = 64
+
+// Bloom is a probabilistic set of ruts, Contains never reports a false
+// negative and reports false positives at about the rate it was sized for
+type Bloom struct {
+	words []uint64
+	m     uint64 // bits
+	k     uint32 // hashes per rut
+	n     uint64 // added ruts
+}
+
+// NewBloom returns a filter sized for n ruts with a false positive rate fp
+func NewBloom(n int, fp float64) (*Bloom, error) {
+	if fp <= 0 || fp >= 1 {
+		return nil, ErrFalsePositiveRate
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2)))
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = min(max(k, 1), maxBloomHashes)
+	return &Bloom{
+		words: make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+	}, nil
+}
+
+// Add validates r and adds it
+func (b *Bloom) Add(r rut.Rut) error {
+	body, err := r.Body()
+	if err != nil {
+		return err
+	}
+
+	h1, h2 := hash(uint32(body))
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		b.words[bit/64] |= 1 << (bit % 64)
+	}
+	b.n++
+	return nil
+}
+
+// Contains reports whether r is valid and probably present
+func (b *Bloom) Contains(r rut.Rut) bool {
+	body, err := r.Body()
+	if err != nil {
+		return false
+	}
+
+	h1, h2 := hash(uint32(body))
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of added ruts, counting repeated ones
+func (b *Bloom) Len() int {
+	return int(b.n)
+}
+
+// MarshalBinary encodes the filter in a versioned little endian format
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 25+8*len(b.words))
+	data = append(data, bloommagic[:]...)
+	data = append(data, bloomversion)
+	data = binary.LittleEndian.AppendUint32(data, b.k)
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	data = binary.LittleEndian.AppendUint64(data, b.n)
+	for _, w := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	if len(data) < 25 || [4]byte(data[:4]) != bloommagic || data[4] != bloomversion {
+		return ErrInvalidBloom
+	}
+
+	k := binary.LittleEndian.Uint32(data[5:])
+	m := binary.LittleEndian.Uint64(data[9:])
+	n := binary.LittleEndian.Uint64(data[17:])
+	data = data[25:]
+	// bounding m by the payload first keeps (m+63)/64 from overflowing
+	if k == 0 || k > maxBloomHashes || m == 0 || m > uint64(len(data))*8 || uint64(len(data)) != (m+63)/64*8 {
+		return ErrInvalidBloom
+	}
+
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	*b = Bloom{words: words, m: m, k: k, n: n}
+	return nil
+}
+
+// hash derives the two hashes of the double hashing scheme from the
+// 'cuerpo' with the splitmix64 finalizer, h2 is odd so it never degenerates
+func hash(body uint32) (h1, h2 uint64) {
+	x := uint64(body) + 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return x, (x>>32 | x<<32) | 1
+}
@@ -0,0 +1,102 @@
+package rutset
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestBloom(t *testing.T) {
+	b, err := NewBloom(10000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for body := 10000000; body < 10010000; body++ {
+		r, _ := rut.FromBody(body)
+		if err := b.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Add("10000000-0"); err == nil {
+		t.Error("invalid rut added")
+	}
+
+	for body := 10000000; body < 10010000; body++ {
+		r, _ := rut.FromBody(body)
+		if !b.Contains(r) {
+			t.Fatal("false negative", r)
+		}
+	}
+
+	var fp int
+	for body := 20000000; body < 20100000; body++ {
+		r, _ := rut.FromBody(body)
+		if b.Contains(r) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 100000; rate > 0.02 {
+		t.Error("false positive rate too high", rate)
+	}
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Bloom
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Len() != b.Len() || !decoded.Contains("10000000-8") {
+		t.Error("decoded filter differs")
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err != ErrInvalidBloom {
+		t.Error("expected", ErrInvalidBloom, "got", err)
+	}
+
+	// a crafted m overflowing the word count
+	crafted := slices.Clone(data[:25])
+	binary.LittleEndian.PutUint64(crafted[9:], math.MaxUint64)
+	if err := decoded.UnmarshalBinary(crafted); err != ErrInvalidBloom {
+		t.Error("expected", ErrInvalidBloom, "got", err)
+	}
+
+	// corrupt headers: no hashes, billions of them and a bit length
+	// the payload doesn't match
+	for _, corrupt := range []func([]byte){
+		func(h []byte) { binary.LittleEndian.PutUint32(h[5:], 0) },
+		func(h []byte) { binary.LittleEndian.PutUint32(h[5:], math.MaxUint32) },
+		func(h []byte) { binary.LittleEndian.PutUint32(h[5:], maxBloomHashes+1) },
+		func(h []byte) { binary.LittleEndian.PutUint64(h[9:], b.m+64) },
+		func(h []byte) { binary.LittleEndian.PutUint64(h[9:], b.m-64) },
+	} {
+		crafted := slices.Clone(data)
+		corrupt(crafted)
+		if err := decoded.UnmarshalBinary(crafted); err != ErrInvalidBloom {
+			t.Error("expected", ErrInvalidBloom, "got", err)
+		}
+	}
+}
+
+func TestNewBloom(t *testing.T) {
+	for _, fp := range []float64{0, 1, -0.5} {
+		if _, err := NewBloom(10, fp); err != ErrFalsePositiveRate {
+			t.Error(fp, "expected", ErrFalsePositiveRate, "got", err)
+		}
+	}
+
+	// a tiny rate still encodes a filter UnmarshalBinary accepts
+	b, err := NewBloom(10, 1e-30)
+	if err != nil || b.k != maxBloomHashes {
+		t.Fatal("unexpected filter", b, err)
+	}
+	data, _ := b.MarshalBinary()
+	if err := new(Bloom).UnmarshalBinary(data); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,51 @@
+package rutset
+
+import (
+	"errors"
+	"io"
+
+	"github.com/alvarolm/rut/internal/bitmap"
+)
+
+var ErrInvalidSet = errors.New("invalid rut set encoding")
+
+// set encoding: a header with the magic and the version
+// followed by the bitmap pages
+var setmagic = [4]byte{'R', 'U', 'T', 'S'}
+
+const setversion = 1
+
+// WriteTo encodes the set in a versioned format readable by ReadFrom,
+// meant to build a set once and load it in many processes
+func (s *Set) WriteTo(w io.Writer) (n int64, err error) {
+	header := append(setmagic[:], setversion)
+	m, err := w.Write(header)
+	if n = int64(m); err != nil {
+		return
+	}
+
+	written, err := s.bodies.WriteTo(w)
+	return n + written, err
+}
+
+// ReadFrom replaces the set with one encoded by WriteTo
+func (s *Set) ReadFrom(r io.Reader) (n int64, err error) {
+	var header [len(setmagic) + 1]byte
+	m, err := io.ReadFull(r, header[:])
+	if n = int64(m); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = ErrInvalidSet
+		}
+		return
+	}
+	if [4]byte(header[:4]) != setmagic || header[4] != setversion {
+		return n, ErrInvalidSet
+	}
+
+	read, err := s.bodies.ReadFrom(r)
+	switch n += read; err {
+	case bitmap.ErrCorrupt, io.EOF, io.ErrUnexpectedEOF:
+		err = ErrInvalidSet
+	}
+	return
+}
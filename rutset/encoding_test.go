@@ -0,0 +1,38 @@
+package rutset
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	s, _ := New("5126663-3", "11111111-1", "13117182-K", "99999999-9")
+
+	var buf bytes.Buffer
+	n, err := s.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Error("expected", buf.Len(), "written bytes, got", n)
+	}
+	data := buf.Bytes()
+
+	var decoded Set
+	if n, err = decoded.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Error("expected", len(data), "read bytes, got", n)
+	}
+	if !slices.Equal(ruts(&decoded), ruts(s)) || decoded.Len() != s.Len() {
+		t.Error("decoded set differs", ruts(&decoded))
+	}
+
+	for _, corrupt := range [][]byte{nil, []byte("RUTS"), append([]byte("RUTX"), data[4:]...), data[:len(data)-1]} {
+		if _, err := decoded.ReadFrom(bytes.NewReader(corrupt)); err != ErrInvalidSet {
+			t.Errorf("%.8q: expected %v, got %v", corrupt, ErrInvalidSet, err)
+		}
+	}
+}
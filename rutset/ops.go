@@ -0,0 +1,100 @@
+package rutset
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/internal/bitmap"
+)
+
+var ErrUnsorted = errors.New("input not sorted by 'cuerpo'")
+
+// Union returns a new set with the ruts in a or b
+func Union(a, b *Set) *Set {
+	return &Set{bodies: *bitmap.Union(&a.bodies, &b.bodies)}
+}
+
+// Intersect returns a new set with the ruts in both a and b
+func Intersect(a, b *Set) *Set {
+	return &Set{bodies: *bitmap.Intersect(&a.bodies, &b.bodies)}
+}
+
+// Difference returns a new set with the ruts in a but not in b
+func Difference(a, b *Set) *Set {
+	return &Set{bodies: *bitmap.Difference(&a.bodies, &b.bodies)}
+}
+
+// Merge walks two inputs sorted by 'cuerpo' in a single pass and calls fn
+// with every distinct rut, in normalized form and ascending order, and
+// whether it's in a, b or both; until fn returns false.
+// it keeps no more than one rut per input in memory, so inputs of any size
+// can be reconciled. invalid ruts are ignored, it returns ErrUnsorted as
+// soon as an input goes backwards
+func Merge(a, b iter.Seq[rut.Rut], fn func(r rut.Rut, inA, inB bool) bool) error {
+	nextA, stopA := iter.Pull(a)
+	defer stopA()
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+
+	lastA, lastB := -1, -1
+	bodyA, okA, err := pull(nextA, &lastA)
+	if err != nil {
+		return err
+	}
+	bodyB, okB, err := pull(nextB, &lastB)
+	if err != nil {
+		return err
+	}
+
+	for okA || okB {
+		inA := okA && (!okB || bodyA <= bodyB)
+		inB := okB && (!okA || bodyB <= bodyA)
+
+		body := bodyA
+		if !inA {
+			body = bodyB
+		}
+		r, err := rut.FromBody(body)
+		if err != nil {
+			return err
+		}
+		if !fn(r, inA, inB) {
+			return nil
+		}
+
+		if inA {
+			if bodyA, okA, err = pull(nextA, &lastA); err != nil {
+				return err
+			}
+		}
+		if inB {
+			if bodyB, okB, err = pull(nextB, &lastB); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pull returns the next distinct valid 'cuerpo' greater than last
+func pull(next func() (rut.Rut, bool), last *int) (body int, ok bool, err error) {
+	for {
+		r, ok := next()
+		if !ok {
+			return 0, false, nil
+		}
+		body, err := r.Body()
+		if err != nil {
+			continue
+		}
+		if body < *last {
+			return 0, false, ErrUnsorted
+		}
+		if body == *last {
+			continue
+		}
+		*last = body
+		return body, true, nil
+	}
+}
@@ -0,0 +1,62 @@
+package rutset
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func ruts(s *Set) (all []rut.Rut) {
+	for r := range s.Iterate {
+		all = append(all, r)
+	}
+	return
+}
+
+func TestOps(t *testing.T) {
+	a, _ := New("5126663-3", "11111111-1", "12345678-5")
+	b, _ := New("11111111-1", "13117182-K")
+
+	if got := ruts(Union(a, b)); !slices.Equal(got, []rut.Rut{"5126663-3", "11111111-1", "12345678-5", "13117182-K"}) {
+		t.Error("unexpected union", got)
+	}
+	if got := ruts(Intersect(a, b)); !slices.Equal(got, []rut.Rut{"11111111-1"}) {
+		t.Error("unexpected intersection", got)
+	}
+	if got := ruts(Difference(a, b)); !slices.Equal(got, []rut.Rut{"5126663-3", "12345678-5"}) {
+		t.Error("unexpected difference", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := []rut.Rut{"5126663-3", "11.111.111-1", "11111111-1", "invalid", "12345678-5"}
+	b := []rut.Rut{"11111111-1", "13117182-k"}
+
+	var got []string
+	err := Merge(slices.Values(a), slices.Values(b), func(r rut.Rut, inA, inB bool) bool {
+		switch {
+		case inA && inB:
+			got = append(got, "="+string(r))
+		case inA:
+			got = append(got, "<"+string(r))
+		default:
+			got = append(got, ">"+string(r))
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"<5126663-3", "=11111111-1", "<12345678-5", ">13117182-K"}
+	if !slices.Equal(got, expected) {
+		t.Error("expected", expected, "got", got)
+	}
+
+	unsorted := []rut.Rut{"12345678-5", "11111111-1"}
+	err = Merge(slices.Values(unsorted), slices.Values(b), func(rut.Rut, bool, bool) bool { return true })
+	if err != ErrUnsorted {
+		t.Error("expected", ErrUnsorted, "got", err)
+	}
+}
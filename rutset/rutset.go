@@ -0,0 +1,95 @@
+/*
+Package rutset implements memory efficient sets of 'Rol Único Tributario'.
+
+the 'digito verificador' is determined by the 'cuerpo', so a set only
+keeps one bit per 'cuerpo' in a paged bitmap, 20 million ruts take a few
+megabytes instead of the gigabytes of a map[string]struct{}
+*/
+package rutset
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/alvarolm/rut"
+	"github.com/alvarolm/rut/internal/bitmap"
+)
+
+// Set is a set of valid ruts, the zero value is an empty set.
+// it isn't safe for concurrent writes
+type Set struct {
+	bodies bitmap.Bitmap
+}
+
+// New returns a set with ruts, it fails on the first invalid one
+func New(ruts ...rut.Rut) (*Set, error) {
+	s := new(Set)
+	for _, r := range ruts {
+		if err := s.Add(r); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add validates r and adds it, adding a present rut is a no-op
+func (s *Set) Add(r rut.Rut) error {
+	body, err := r.Body()
+	if err != nil {
+		return err
+	}
+	s.bodies.Set(uint32(body))
+	return nil
+}
+
+// Contains reports whether r is valid and present, by normalized value
+func (s *Set) Contains(r rut.Rut) bool {
+	body, err := r.Body()
+	return err == nil && s.bodies.Has(uint32(body))
+}
+
+// Len returns the number of ruts
+func (s *Set) Len() int {
+	return s.bodies.Len()
+}
+
+// Iterate calls fn with every rut in normalized form, sorted by 'cuerpo',
+// until fn returns false. Iterate is an iter.Seq[rut.Rut], the 'cuerpos'
+// FromBody rejects since MinRutlength or MaxRutlength changed are skipped
+func (s *Set) Iterate(fn func(rut.Rut) bool) {
+	s.bodies.Iterate(func(body uint32) bool {
+		r, err := rut.FromBody(int(body))
+		if err != nil {
+			return true
+		}
+		return fn(r)
+	})
+}
+
+// Load adds the ruts of r, one per line. blank lines are ignored and
+// invalid ones skipped, both are accounted in the report. lines may end
+// in "\r\n" and have any length, a huge line is one more invalid rut
+func (s *Set) Load(r io.Reader) (report rut.Report, err error) {
+	br := bufio.NewReader(r)
+	for {
+		var line string
+		line, err = br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+		eof := err == io.EOF
+
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			res := rut.Check(line)
+			report.Add(res)
+			if res.Err == nil {
+				s.Add(res.Rut)
+			}
+		}
+
+		if eof {
+			return report, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package rutset
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestSet(t *testing.T) {
+	s, err := New("12.345.678-5", "11111111-1", "12345678-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add("12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected", rut.ErrinvalidDV, "got", err)
+	}
+
+	if s.Len() != 2 {
+		t.Error("expected 2 ruts, got", s.Len())
+	}
+	if !s.Contains("12345678-5") || s.Contains("13117182-K") || s.Contains("garbage") {
+		t.Error("unexpected Contains")
+	}
+
+	var all []rut.Rut
+	for r := range s.Iterate {
+		all = append(all, r)
+	}
+	if !slices.Equal(all, []rut.Rut{"11111111-1", "12345678-5"}) {
+		t.Error("unexpected ruts", all)
+	}
+}
+
+func TestSetNineDigitBodies(t *testing.T) {
+	var s Set
+	if err := s.Add("123.456.789-2"); err != rut.ErrMaxLength {
+		t.Error("expected", rut.ErrMaxLength, "got", err)
+	}
+
+	defer func(max int) { rut.MaxRutlength = max }(rut.MaxRutlength)
+	rut.MaxRutlength = rut.NineDigitRutlength
+	if err := s.Add("123.456.789-2"); err != nil {
+		t.Fatal(err)
+	}
+	if all := ruts(&s); !slices.Equal(all, []rut.Rut{"123456789-2"}) {
+		t.Error("unexpected ruts", all)
+	}
+
+	// no longer a rut once MaxRutlength is restored
+	rut.MaxRutlength = 10
+	if all := ruts(&s); len(all) != 0 {
+		t.Error("unexpected ruts", all)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var s Set
+	report, err := s.Load(strings.NewReader("11111111-1\n\n13117182-k\n13117182-0\n11.111.111-1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || report.Total != 4 || report.Invalid != 1 || report.Duplicates != 1 {
+		t.Error("unexpected load", s.Len(), report)
+	}
+}
+
+func TestLoadCRLF(t *testing.T) {
+	var s Set
+	report, err := s.Load(strings.NewReader("11111111-1\r\n\r\n13117182-k\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || report.Total != 2 || report.Invalid != 0 {
+		t.Error("unexpected load", s.Len(), report)
+	}
+}
+
+func TestLoadLongLine(t *testing.T) {
+	var s Set
+	report, err := s.Load(strings.NewReader("11111111-1\n" + strings.Repeat("1", 100000) + "\n13117182-k"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || report.Total != 3 || report.Errors["max_length"] != 1 {
+		t.Error("unexpected load", s.Len(), report)
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	ruts := make([]rut.Rut, 1<<16)
+	for i := range ruts {
+		ruts[i] = rut.GenerateRut(1000000, 27000000)
+	}
+	r := rand.New(rand.NewSource(1))
+
+	var s Set
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(ruts[r.Intn(len(ruts))])
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Package rutvalidator adds the rut tag to go-playground/validator, it
+validates string, rut.Rut and *rut.Rut fields
+
+	v := validator.New()
+	rutvalidator.Register(v)
+
+	type Client struct {
+		RUT rut.Rut `validate:"rut"`
+		Ref string  `validate:"omitempty,rut"`
+	}
+*/
+package rutvalidator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/alvarolm/rut"
+)
+
+// Tag is the validation tag
+const Tag = "rut"
+
+// Register adds the Tag validation to v
+func Register(v *validator.Validate) error {
+	return v.RegisterValidation(Tag, valid)
+}
+
+func valid(fl validator.FieldLevel) bool {
+	_, err := check(fl.Field())
+	return err == nil
+}
+
+// check validates a string kinded value, pointers are dereferenced
+func check(v reflect.Value) (rut.Rut, error) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", rut.ErrMinLength
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return "", rut.ErrExpectedDigit
+	}
+	return rut.Parse(v.String())
+}
+
+// Error returns the rut error of the first field of err failing the Tag
+// validation, prefixed by the field namespace, or nil when there's none.
+// rut.Code of the returned error is the failure code
+func Error(err error) error {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	for _, fe := range errs {
+		if fe.Tag() != Tag {
+			continue
+		}
+		_, rerr := check(reflect.ValueOf(fe.Value()))
+		if rerr == nil {
+			// the value passed, it's unlikely the tag failed
+			continue
+		}
+		return fmt.Errorf("%s: %w", fe.Namespace(), rerr)
+	}
+	return nil
+}
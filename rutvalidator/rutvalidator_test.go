@@ -0,0 +1,51 @@
+package rutvalidator
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/alvarolm/rut"
+)
+
+type client struct {
+	RUT rut.Rut  `validate:"rut"`
+	Ref *rut.Rut `validate:"omitempty,rut"`
+	Raw string   `validate:"omitempty,rut"`
+}
+
+func TestRegister(t *testing.T) {
+	v := validator.New()
+	if err := Register(v); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := rut.Rut("13.117.182-k")
+	if err := v.Struct(client{RUT: "12.345.678-5", Ref: &ref, Raw: "11111111-1"}); err != nil {
+		t.Error("unexpected error", err)
+	}
+	if err := v.Struct(client{RUT: "12345678-5"}); err != nil {
+		t.Error("unexpected error", err)
+	}
+
+	err := v.Struct(client{RUT: "12345678-5", Raw: "12345678-0"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	rerr := Error(err)
+	if rut.Code(rerr) != "invalid_dv" || rerr.Error() != "client.Raw: invalid 'digito verificador'" {
+		t.Error("unexpected error", rerr)
+	}
+
+	bad := rut.Rut("1-9")
+	if err := Error(v.Struct(client{RUT: "12345678-5", Ref: &bad})); rut.Code(err) != "min_length" {
+		t.Error("expected min_length, got", err)
+	}
+	if err := Error(v.Struct(client{})); rut.Code(err) != "min_length" {
+		t.Error("expected min_length, got", err)
+	}
+
+	if Error(nil) != nil {
+		t.Error("expected nil")
+	}
+}
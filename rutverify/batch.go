@@ -0,0 +1,124 @@
+package rutverify
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alvarolm/rut"
+)
+
+// Result is the outcome of verifying one rut
+type Result struct {
+	Rut    rut.Rut
+	Status Status
+	Err    error
+}
+
+// VerifyBatch verifies every rut with at most concurrency lookups in flight,
+// results are in the same order as ruts. concurrency <= 0 means 1.
+// once ctx is cancelled the pending ruts get ctx.Err() as their Err and
+// VerifyBatch returns the partial results along with ctx.Err()
+func VerifyBatch(ctx context.Context, v Verifier, ruts []rut.Rut, concurrency int) ([]Result, error) {
+	results := make([]Result, len(ruts))
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > len(ruts) {
+		concurrency = len(ruts)
+	}
+
+	var (
+		next int64
+		wg   sync.WaitGroup
+		done = ctx.Done()
+	)
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= len(ruts) {
+					return
+				}
+				st, err := v.Verify(ctx, ruts[i])
+				results[i] = Result{Rut: ruts[i], Status: st, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// every claimed index was processed, the rest were left by a cancellation
+	for i := int(next); i < len(ruts); i++ {
+		results[i] = Result{Rut: ruts[i], Err: ctx.Err()}
+	}
+	if int(next) < len(ruts) {
+		return results, ctx.Err()
+	}
+	return results, nil
+}
+
+// Limiter paces the lookups, golang.org/x/time/rate.Limiter implements it
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimit returns v waiting for l before every lookup
+func RateLimit(v Verifier, l Limiter) Verifier {
+	return VerifierFunc(func(ctx context.Context, r rut.Rut) (Status, error) {
+		if err := l.Wait(ctx); err != nil {
+			return StatusUnknown, err
+		}
+		return v.Verify(ctx, r)
+	})
+}
+
+// Interval returns a Limiter allowing one lookup every d
+func Interval(d time.Duration) Limiter {
+	return &interval{every: d}
+}
+
+type interval struct {
+	every time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func (l *interval) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.every)
+	l.mu.Unlock()
+
+	if !at.After(now) {
+		return nil
+	}
+	if err := sleep(ctx, at.Sub(now)); err != nil {
+		// gives the slot back, unless later ones were already taken
+		l.mu.Lock()
+		if l.next.Equal(at.Add(l.every)) {
+			l.next = at
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
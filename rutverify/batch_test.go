@@ -0,0 +1,105 @@
+package rutverify
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	m, _ := NewMock(map[rut.Rut]Status{"11111111-1": StatusActive})
+	ruts := []rut.Rut{"11111111-1", "12345678-5", "12345678-0"}
+
+	results, err := VerifyBatch(context.Background(), m, ruts, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Status != StatusActive || results[1].Status != StatusNotFound || results[2].Err != rut.ErrinvalidDV {
+		t.Error("unexpected results", results)
+	}
+	for i, res := range results {
+		if res.Rut != ruts[i] {
+			t.Error("results out of order", results)
+		}
+	}
+}
+
+func TestVerifyBatchConcurrency(t *testing.T) {
+	var inflight, peak atomic.Int32
+	v := VerifierFunc(func(ctx context.Context, r rut.Rut) (Status, error) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return StatusActive, nil
+	})
+
+	ruts := make([]rut.Rut, 50)
+	for i := range ruts {
+		ruts[i] = "11111111-1"
+	}
+	if _, err := VerifyBatch(context.Background(), v, ruts, 3); err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Error("expected at most 3 lookups in flight, got", p)
+	}
+}
+
+func TestVerifyBatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	v := VerifierFunc(func(ctx context.Context, r rut.Rut) (Status, error) {
+		if calls.Add(1) == 5 {
+			cancel()
+		}
+		return StatusActive, nil
+	})
+
+	ruts := make([]rut.Rut, 100)
+	for i := range ruts {
+		ruts[i] = "11111111-1"
+	}
+	results, err := VerifyBatch(ctx, v, ruts, 1)
+	if err != context.Canceled {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+	var verified int
+	for _, res := range results {
+		if res.Err == nil {
+			verified++
+		} else if res.Err != context.Canceled {
+			t.Error("unexpected error", res.Err)
+		}
+	}
+	if verified != 5 {
+		t.Error("expected 5 partial results, got", verified)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	m, _ := NewMock(nil)
+	v := RateLimit(m, Interval(5*time.Millisecond))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		v.Verify(context.Background(), "11111111-1")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Error("expected at least 20ms, got", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := RateLimit(m, Interval(time.Hour)).Verify(ctx, "11111111-1"); err != context.Canceled {
+		t.Error("expected context.Canceled, got", err)
+	}
+}
@@ -0,0 +1,208 @@
+package rutverify
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alvarolm/rut"
+)
+
+// DefaultCacheTTL is the CacheOptions.TTL used when unset
+const DefaultCacheTTL = 24 * time.Hour
+
+// CacheOptions configures a Cache
+type CacheOptions struct {
+	// TTL is how long a status is reused, defaults to DefaultCacheTTL
+	TTL time.Duration
+
+	// MaxEntries bounds the cached statuses, the least recently used is
+	// evicted first. 0 means no limit
+	MaxEntries int
+
+	// Now defaults to time.Now
+	Now func() time.Time
+}
+
+// Cache is a Verifier reusing the statuses of another one, concurrent
+// lookups of the same rut share a single call. errors aren't cached
+type Cache struct {
+	v    Verifier
+	opts CacheOptions
+
+	mu      sync.Mutex
+	entries map[rut.Rut]*list.Element
+	lru     list.List
+	calls   map[rut.Rut]*call
+}
+
+type cacheEntry struct {
+	Rut     rut.Rut   `json:"rut"`
+	Status  Status    `json:"status"`
+	Expires time.Time `json:"expires"`
+}
+
+// call is an in flight lookup
+type call struct {
+	done chan struct{}
+	st   Status
+	err  error
+}
+
+// NewCache returns a Cache in front of v
+func NewCache(v Verifier, opts CacheOptions) *Cache {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Cache{
+		v:       v,
+		opts:    opts,
+		entries: make(map[rut.Rut]*list.Element),
+		calls:   make(map[rut.Rut]*call),
+	}
+}
+
+// Verify returns the cached status of r or looks it up
+func (c *Cache) Verify(ctx context.Context, r rut.Rut) (Status, error) {
+	r, err := normalize(r)
+	if err != nil {
+		return StatusUnknown, err
+	}
+
+	for {
+		c.mu.Lock()
+		if st, ok := c.get(r); ok {
+			c.mu.Unlock()
+			return st, nil
+		}
+		if cl, ok := c.calls[r]; ok {
+			c.mu.Unlock()
+			select {
+			case <-cl.done:
+			case <-ctx.Done():
+				return StatusUnknown, ctx.Err()
+			}
+			// the lookup was canceled by its own caller, not this one
+			if isContextErr(cl.err) && ctx.Err() == nil {
+				continue
+			}
+			return cl.st, cl.err
+		}
+
+		cl := &call{done: make(chan struct{})}
+		c.calls[r] = cl
+		c.mu.Unlock()
+
+		cl.st, cl.err = c.v.Verify(ctx, r)
+
+		c.mu.Lock()
+		delete(c.calls, r)
+		if cl.err == nil {
+			c.put(cacheEntry{r, cl.st, c.opts.Now().Add(c.opts.TTL)})
+		}
+		c.mu.Unlock()
+		close(cl.done)
+		return cl.st, cl.err
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// get returns the unexpired status of r, c.mu must be held
+func (c *Cache) get(r rut.Rut) (Status, bool) {
+	el, ok := c.entries[r]
+	if !ok {
+		return StatusUnknown, false
+	}
+	e := el.Value.(*cacheEntry)
+	if !c.opts.Now().Before(e.Expires) {
+		c.lru.Remove(el)
+		delete(c.entries, r)
+		return StatusUnknown, false
+	}
+	c.lru.MoveToFront(el)
+	return e.Status, true
+}
+
+// put stores e evicting the least recently used entries, c.mu must be held
+func (c *Cache) put(e cacheEntry) {
+	if el, ok := c.entries[e.Rut]; ok {
+		*el.Value.(*cacheEntry) = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[e.Rut] = c.lru.PushFront(&e)
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).Rut)
+	}
+}
+
+// Len returns the number of cached statuses, expired ones included
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge removes every cached status
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	clear(c.entries)
+}
+
+// Save writes the unexpired statuses as JSON lines, most recently used last,
+// so Load restores the same eviction order
+func (c *Cache) Save(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.opts.Now()
+	enc := json.NewEncoder(w)
+	for el := c.lru.Back(); el != nil; el = el.Prev() {
+		e := el.Value.(*cacheEntry)
+		if !now.Before(e.Expires) {
+			continue
+		}
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load adds the unexpired statuses written by Save
+func (c *Cache) Load(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.opts.Now()
+	for {
+		var e cacheEntry
+		if err := dec.Decode(&e); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var err error
+		if e.Rut, err = normalize(e.Rut); err != nil {
+			return err
+		}
+		if now.Before(e.Expires) {
+			c.put(e)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package rutverify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestCache(t *testing.T) {
+	m, _ := NewMock(map[rut.Rut]Status{"11111111-1": StatusActive})
+	now := time.Unix(0, 0)
+	c := NewCache(m, CacheOptions{TTL: time.Hour, MaxEntries: 2, Now: func() time.Time { return now }})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if st, err := c.Verify(ctx, "11.111.111-1"); err != nil || st != StatusActive {
+			t.Fatal("unexpected status", st, err)
+		}
+	}
+	if n := len(m.Calls()); n != 1 {
+		t.Error("expected 1 lookup, got", n)
+	}
+
+	now = now.Add(time.Hour)
+	c.Verify(ctx, "11111111-1")
+	if n := len(m.Calls()); n != 2 {
+		t.Error("expected an expired lookup, got", n)
+	}
+
+	c.Verify(ctx, "12345678-5")
+	c.Verify(ctx, "13117182-K")
+	if c.Len() != 2 {
+		t.Error("expected 2 entries, got", c.Len())
+	}
+	c.Verify(ctx, "11111111-1")
+	if n := len(m.Calls()); n != 5 {
+		t.Error("expected an evicted lookup, got", n)
+	}
+
+	failure := errors.New("unavailable")
+	m.SetError("22222222-2", failure)
+	c.Verify(ctx, "22222222-2")
+	if _, err := c.Verify(ctx, "22222222-2"); err != failure {
+		t.Error("expected failure, got", err)
+	}
+	if n := len(m.Calls()); n != 7 {
+		t.Error("errors must not be cached, got", n)
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Error("expected empty cache")
+	}
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	var lookups atomic.Int32
+	release := make(chan struct{})
+	v := VerifierFunc(func(ctx context.Context, r rut.Rut) (Status, error) {
+		lookups.Add(1)
+		<-release
+		return StatusActive, nil
+	})
+	c := NewCache(v, CacheOptions{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if st, err := c.Verify(context.Background(), "11111111-1"); err != nil || st != StatusActive {
+				t.Error("unexpected status", st, err)
+			}
+		}()
+	}
+	for lookups.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := lookups.Load(); n != 1 {
+		t.Error("expected 1 lookup, got", n)
+	}
+}
+
+func TestCacheCanceledLeader(t *testing.T) {
+	started := make(chan struct{}, 2)
+	v := VerifierFunc(func(ctx context.Context, r rut.Rut) (Status, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return StatusUnknown, ctx.Err()
+	})
+	c := NewCache(v, CacheOptions{})
+
+	leader, cancel := context.WithCancel(context.Background())
+	go c.Verify(leader, "11111111-1")
+	<-started
+
+	waiter, stop := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer stop()
+	done := make(chan error)
+	go func() {
+		_, err := c.Verify(waiter, "11111111-1")
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// the waiter takes over the lookup instead of failing with the leader
+	<-started
+	if err := <-done; err != context.DeadlineExceeded {
+		t.Error("expected the waiter deadline, got", err)
+	}
+}
+
+func TestCacheSaveLoad(t *testing.T) {
+	m, _ := NewMock(map[rut.Rut]Status{"11111111-1": StatusActive, "13117182-K": StatusRegistered})
+	now := time.Unix(0, 0)
+	opts := CacheOptions{TTL: time.Hour, Now: func() time.Time { return now }}
+	c := NewCache(m, opts)
+	c.Verify(context.Background(), "11111111-1")
+	c.Verify(context.Background(), "13117182-K")
+
+	var b bytes.Buffer
+	if err := c.Save(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewCache(m, opts)
+	if err := restored.Load(bytes.NewReader(b.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := restored.Verify(context.Background(), "13117182-k"); st != StatusRegistered || len(m.Calls()) != 2 {
+		t.Error("expected the restored status", st, m.Calls())
+	}
+
+	now = now.Add(2 * time.Hour)
+	expired := NewCache(m, opts)
+	expired.Load(bytes.NewReader(b.Bytes()))
+	if expired.Len() != 0 {
+		t.Error("expected expired entries to be skipped")
+	}
+}
@@ -0,0 +1,99 @@
+package rutverify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/alvarolm/rut"
+)
+
+// Mock is an in-memory Verifier for tests, ruts without a status verify as
+// Default. it's safe for concurrent use
+type Mock struct {
+	// Default is the status of unknown ruts, StatusNotFound unless changed
+	Default Status
+
+	mu       sync.Mutex
+	statuses map[rut.Rut]Status
+	errs     map[rut.Rut]error
+	calls    []rut.Rut
+}
+
+// NewMock returns a Mock seeded with statuses, keys are normalized
+func NewMock(statuses map[rut.Rut]Status) (*Mock, error) {
+	m := &Mock{Default: StatusNotFound, statuses: make(map[rut.Rut]Status), errs: make(map[rut.Rut]error)}
+	for r, st := range statuses {
+		if err := m.Set(r, st); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// LoadMock returns a Mock seeded from a JSON object fixture
+// mapping ruts to status names
+//
+//	{"11.111.111-1": "active", "22222222-2": "not_found"}
+func LoadMock(r io.Reader) (*Mock, error) {
+	var fixture map[rut.Rut]Status
+	if err := json.NewDecoder(r).Decode(&fixture); err != nil {
+		return nil, err
+	}
+	return NewMock(fixture)
+}
+
+// Set sets the status of r
+func (m *Mock) Set(r rut.Rut, st Status) error {
+	r, err := normalize(r)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[r] = st
+	delete(m.errs, r)
+	return nil
+}
+
+// SetError makes the verification of r fail with err
+func (m *Mock) SetError(r rut.Rut, err error) error {
+	r, verr := normalize(r)
+	if verr != nil {
+		return verr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs[r] = err
+	return nil
+}
+
+// Verify returns the status set for r
+func (m *Mock) Verify(ctx context.Context, r rut.Rut) (Status, error) {
+	if err := ctx.Err(); err != nil {
+		return StatusUnknown, err
+	}
+	r, err := normalize(r)
+	if err != nil {
+		return StatusUnknown, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, r)
+	if err, ok := m.errs[r]; ok {
+		return StatusUnknown, err
+	}
+	if st, ok := m.statuses[r]; ok {
+		return st, nil
+	}
+	return m.Default, nil
+}
+
+// Calls returns the normalized ruts verified so far, in order
+func (m *Mock) Calls() []rut.Rut {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]rut.Rut(nil), m.calls...)
+}
@@ -0,0 +1,51 @@
+package rutverify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestMock(t *testing.T) {
+	m, err := LoadMock(strings.NewReader(`{"11.111.111-1": "active", "13117182-k": "registered"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for r, expected := range map[rut.Rut]Status{
+		"11111111-1":   StatusActive,
+		"13.117.182-K": StatusRegistered,
+		"12345678-5":   StatusNotFound,
+	} {
+		if st, err := m.Verify(ctx, r); err != nil || st != expected {
+			t.Error(r, "expected", expected, "got", st, err)
+		}
+	}
+
+	failure := errors.New("unavailable")
+	if err := m.SetError("12.345.678-5", failure); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Verify(ctx, "12345678-5"); err != failure {
+		t.Error("expected failure, got", err)
+	}
+	if _, err := m.Verify(ctx, "12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+
+	if calls := m.Calls(); len(calls) != 4 || calls[3] != "12345678-5" {
+		t.Error("unexpected calls", calls)
+	}
+}
+
+func TestLoadMockErrors(t *testing.T) {
+	for _, fixture := range []string{`{"11111111-1": "nope"}`, `{"12345678-0": "active"}`, `[]`} {
+		if _, err := LoadMock(strings.NewReader(fixture)); err == nil {
+			t.Error(fixture, "expected error")
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package rutverify
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("verification service unavailable, circuit open")
+
+// DefaultRetryPolicy retries 3 times waiting 200ms, 400ms and 800ms ±20%
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// RetryPolicy is an exponential backoff with jitter
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, <= 1 doesn't retry
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry,
+	// each retry waits Multiplier times the previous one up to MaxBackoff
+	InitialBackoff, MaxBackoff time.Duration
+
+	// Multiplier defaults to 2
+	Multiplier float64
+
+	// Jitter randomizes every wait by ±Jitter of it, in [0, 1]
+	Jitter float64
+}
+
+// backoff returns the wait after the attempt-th failed attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	mult := p.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= mult
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// sleep waits d or until ctx is done, replaced by the tests
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// temporary marks the failures worth a retry
+type temporary struct {
+	error
+}
+
+func (t temporary) Unwrap() error {
+	return t.error
+}
+
+func isTemporary(err error) bool {
+	var t temporary
+	return errors.As(err, &t)
+}
+
+// Breaker opens after Threshold consecutive temporary failures, failing
+// every lookup with ErrCircuitOpen for Cooldown, then lets a single trial
+// lookup through and closes again when it succeeds
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	// Now defaults to time.Now
+	Now func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func (b *Breaker) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// allow reports ErrCircuitOpen while the breaker is open
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.Threshold <= 0 || b.failures < b.Threshold {
+		return nil
+	}
+	if b.trial || b.now().Sub(b.openedAt) < b.Cooldown {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// record accounts the outcome of an allowed lookup,
+// canceled lookups neither count as failures nor close the breaker
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	switch {
+	case isTemporary(err):
+		if b.failures++; b.failures >= b.Threshold {
+			b.openedAt = b.now()
+		}
+	case isContextErr(err):
+	default:
+		b.failures = 0
+	}
+}
+
+// Open reports whether lookups are currently rejected
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.Threshold > 0 && b.failures >= b.Threshold && (b.trial || b.now().Sub(b.openedAt) < b.Cooldown)
+}
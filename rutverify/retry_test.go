@@ -0,0 +1,141 @@
+package rutverify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, expected := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		50: time.Second,
+	} {
+		if d := p.backoff(attempt); d != expected {
+			t.Error(attempt, "expected", expected, "got", d)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.backoff(1); d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatal("backoff out of the jitter range", d)
+		}
+	}
+}
+
+// flaky serves failures status codes before answering
+func flaky(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("Inicio de Actividades: SI"))
+	}))
+	return srv, &requests
+}
+
+func noSleep(t *testing.T) *[]time.Duration {
+	var waits []time.Duration
+	orig := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &waits
+}
+
+func TestSIIRetry(t *testing.T) {
+	waits := noSleep(t)
+	srv, requests := flaky(2, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	s := &SII{Client: srv.Client(), URL: srv.URL, Retry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}
+	st, err := s.Verify(context.Background(), "11111111-1")
+	if err != nil || st != StatusActive {
+		t.Fatal("unexpected status", st, err)
+	}
+	if requests.Load() != 3 || len(*waits) != 2 {
+		t.Error("unexpected attempts", requests.Load(), *waits)
+	}
+}
+
+func TestSIIRetryPermanent(t *testing.T) {
+	noSleep(t)
+	srv, requests := flaky(10, http.StatusBadRequest)
+	defer srv.Close()
+
+	s := &SII{Client: srv.Client(), URL: srv.URL, Retry: DefaultRetryPolicy}
+	if _, err := s.Verify(context.Background(), "11111111-1"); !errors.Is(err, ErrUnexpectedResponse) {
+		t.Error("expected ErrUnexpectedResponse, got", err)
+	}
+	if requests.Load() != 1 {
+		t.Error("4xx responses must not be retried, got", requests.Load())
+	}
+}
+
+func TestSIIBreaker(t *testing.T) {
+	noSleep(t)
+	srv, requests := flaky(4, http.StatusInternalServerError)
+	defer srv.Close()
+
+	now := time.Unix(0, 0)
+	b := &Breaker{Threshold: 2, Cooldown: time.Minute, Now: func() time.Time { return now }}
+	s := &SII{Client: srv.Client(), URL: srv.URL, Retry: RetryPolicy{MaxAttempts: 2}, Breaker: b}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Verify(ctx, "11111111-1"); !errors.Is(err, ErrUnexpectedResponse) {
+			t.Fatal("expected ErrUnexpectedResponse, got", err)
+		}
+	}
+	if !b.Open() {
+		t.Fatal("expected an open breaker")
+	}
+	if _, err := s.Verify(ctx, "11111111-1"); err != ErrCircuitOpen {
+		t.Error("expected ErrCircuitOpen, got", err)
+	}
+	if requests.Load() != 4 {
+		t.Error("unexpected requests", requests.Load())
+	}
+
+	now = now.Add(time.Minute)
+	if st, err := s.Verify(ctx, "11111111-1"); err != nil || st != StatusActive {
+		t.Fatal("expected the trial lookup to succeed", st, err)
+	}
+	if b.Open() {
+		t.Error("expected a closed breaker")
+	}
+}
+
+func TestBreakerCanceledTrial(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := &Breaker{Threshold: 1, Cooldown: time.Minute, Now: func() time.Time { return now }}
+	b.record(temporary{errors.New("down")})
+	if b.allow() != ErrCircuitOpen {
+		t.Fatal("expected an open breaker")
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatal("expected a trial", err)
+	}
+	if b.allow() != ErrCircuitOpen {
+		t.Error("expected a single trial")
+	}
+	b.record(context.Canceled)
+	if err := b.allow(); err != nil {
+		t.Error("expected another trial after a canceled one", err)
+	}
+}
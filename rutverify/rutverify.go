@@ -0,0 +1,98 @@
+/*
+Package rutverify confirms that valid ruts are actually issued, a correct
+'digito verificador' only means the rut is well formed.
+
+Verifier is implemented by SII, which queries the public 'situación
+tributaria de terceros' service of the Servicio de Impuestos Internos.
+*/
+package rutverify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alvarolm/rut"
+)
+
+var (
+	ErrUnexpectedResponse = errors.New("unexpected verification service response")
+	ErrUnknownStatus      = errors.New("unknown status")
+)
+
+// Status is the registration status of a rut
+type Status int
+
+const (
+	// StatusUnknown is returned along with errors
+	StatusUnknown Status = iota
+
+	// StatusActive ruts are registered and have 'inicio de actividades'
+	StatusActive
+
+	// StatusRegistered ruts are registered without 'inicio de actividades'
+	StatusRegistered
+
+	// StatusNotFound ruts are well formed but not registered
+	StatusNotFound
+)
+
+var statusNames = [...]string{
+	StatusUnknown:    "unknown",
+	StatusActive:     "active",
+	StatusRegistered: "registered",
+	StatusNotFound:   "not_found",
+}
+
+func (s Status) String() string {
+	if s < 0 || int(s) >= len(statusNames) {
+		return "unknown"
+	}
+	return statusNames[s]
+}
+
+// ParseStatus returns the Status of a name returned by String
+func ParseStatus(s string) (Status, error) {
+	for st, name := range statusNames {
+		if name == s {
+			return Status(st), nil
+		}
+	}
+	return StatusUnknown, ErrUnknownStatus
+}
+
+// MarshalText implements encoding.TextMarshaler with the String names
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with ParseStatus
+func (s *Status) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseStatus(string(text))
+	return
+}
+
+// Exists reports whether the rut is registered, active or not
+func (s Status) Exists() bool {
+	return s == StatusActive || s == StatusRegistered
+}
+
+// Verifier looks up the registration status of a rut,
+// implementations must be safe for concurrent use
+type Verifier interface {
+	// Verify returns the status of r, invalid ruts fail with the rut
+	// validation error before any lookup
+	Verify(ctx context.Context, r rut.Rut) (Status, error)
+}
+
+// VerifierFunc adapts a function to a Verifier
+type VerifierFunc func(ctx context.Context, r rut.Rut) (Status, error)
+
+func (f VerifierFunc) Verify(ctx context.Context, r rut.Rut) (Status, error) {
+	return f(ctx, r)
+}
+
+// normalize validates r and returns its canonical form
+func normalize(r rut.Rut) (rut.Rut, error) {
+	_, err := r.Validate()
+	return r, err
+}
@@ -0,0 +1,23 @@
+package rutverify
+
+import (
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	for _, st := range []Status{StatusUnknown, StatusActive, StatusRegistered, StatusNotFound} {
+		parsed, err := ParseStatus(st.String())
+		if err != nil || parsed != st {
+			t.Error(st, "round trip failed", parsed, err)
+		}
+	}
+	if _, err := ParseStatus("nope"); err != ErrUnknownStatus {
+		t.Error("expected ErrUnknownStatus, got", err)
+	}
+	if Status(99).String() != "unknown" {
+		t.Error("unexpected name", Status(99).String())
+	}
+	if !StatusActive.Exists() || !StatusRegistered.Exists() || StatusNotFound.Exists() {
+		t.Error("unexpected Exists")
+	}
+}
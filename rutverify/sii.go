@@ -0,0 +1,164 @@
+package rutverify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/alvarolm/rut"
+)
+
+// DefaultSIIURL is the 'situación tributaria de terceros' query endpoint
+const DefaultSIIURL = "https://zeus.sii.cl/cvc_cgi/stc/getstc"
+
+// maxResponseBytes bounds the read of the SII response
+const maxResponseBytes = 1 << 20
+
+// SII is a Verifier querying the Servicio de Impuestos Internos,
+// the zero value is ready to use
+type SII struct {
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+
+	// URL defaults to DefaultSIIURL
+	URL string
+
+	// Params, if set, adds query parameters to every lookup, eg. the
+	// captcha fields when the service requires them
+	Params func(ctx context.Context, r rut.Rut) (url.Values, error)
+
+	// Parse defaults to ParseSII
+	Parse func(body []byte) (Status, error)
+
+	// Retry retries the lookups failing with network errors, 429 or 5xx
+	// responses, the zero value doesn't retry
+	Retry RetryPolicy
+
+	// Breaker, if set, stops the lookups while the service keeps failing
+	Breaker *Breaker
+}
+
+// Verify queries the status of r
+func (s *SII) Verify(ctx context.Context, r rut.Rut) (st Status, err error) {
+	if r, err = normalize(r); err != nil {
+		return
+	}
+	if s.Breaker != nil {
+		if err = s.Breaker.allow(); err != nil {
+			return
+		}
+		defer func() {
+			s.Breaker.record(err)
+		}()
+	}
+
+	for attempt := 1; ; attempt++ {
+		st, err = s.lookup(ctx, r)
+		if err == nil || !isTemporary(err) || attempt >= s.Retry.MaxAttempts {
+			return
+		}
+		if serr := sleep(ctx, s.Retry.backoff(attempt)); serr != nil {
+			return StatusUnknown, serr
+		}
+	}
+}
+
+// lookup performs a single query, failures worth a retry are temporary
+func (s *SII) lookup(ctx context.Context, r rut.Rut) (Status, error) {
+	req, err := s.request(ctx, r)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return StatusUnknown, ctx.Err()
+		}
+		return StatusUnknown, temporary{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: %s", ErrUnexpectedResponse, resp.Status)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			err = temporary{err}
+		}
+		return StatusUnknown, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return StatusUnknown, temporary{err}
+	}
+
+	parse := s.Parse
+	if parse == nil {
+		parse = ParseSII
+	}
+	return parse(body)
+}
+
+func (s *SII) request(ctx context.Context, r rut.Rut) (*http.Request, error) {
+	endpoint := s.URL
+	if endpoint == "" {
+		endpoint = DefaultSIIURL
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	body, _ := r.Body()
+	q := u.Query()
+	q.Set("RUT", strconv.Itoa(body))
+	q.Set("DV", string(r[len(r)-1]))
+	q.Set("PRG", "STC")
+	q.Set("OPC", "NOR")
+	if s.Params != nil {
+		params, err := s.Params(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range params {
+			q[k] = v
+		}
+	}
+	u.RawQuery = q.Encode()
+	return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+}
+
+// the SII page is latin-1 encoded, these markers are plain ascii
+var (
+	activitiesMarker = []byte("Inicio de Actividades:")
+	notFoundMarkers  = [][]byte{
+		[]byte("no se encuentra registrado"),
+		[]byte("no registra"),
+		[]byte("RUT no v"),
+	}
+)
+
+// ParseSII returns the Status reported by a 'situación tributaria' page
+func ParseSII(body []byte) (Status, error) {
+	if i := bytes.Index(body, activitiesMarker); i >= 0 {
+		answer := bytes.TrimLeft(body[i+len(activitiesMarker):], " \t\r\n")
+		switch {
+		case bytes.HasPrefix(answer, []byte("SI")):
+			return StatusActive, nil
+		case bytes.HasPrefix(answer, []byte("NO")):
+			return StatusRegistered, nil
+		}
+	}
+	for _, marker := range notFoundMarkers {
+		if bytes.Contains(body, marker) {
+			return StatusNotFound, nil
+		}
+	}
+	return StatusUnknown, ErrUnexpectedResponse
+}
@@ -0,0 +1,63 @@
+package rutverify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/alvarolm/rut"
+)
+
+func TestParseSII(t *testing.T) {
+	for body, expected := range map[string]Status{
+		"<span>Contribuyente presenta Inicio de Actividades: SI</span>":   StatusActive,
+		"<span>Contribuyente presenta Inicio de Actividades:\n NO</span>": StatusRegistered,
+		"<p>El RUT consultado no se encuentra registrado</p>":             StatusNotFound,
+	} {
+		st, err := ParseSII([]byte(body))
+		if err != nil || st != expected {
+			t.Error(body, "expected", expected, "got", st, err)
+		}
+	}
+	if _, err := ParseSII([]byte("<html>mantención</html>")); err != ErrUnexpectedResponse {
+		t.Error("expected ErrUnexpectedResponse, got", err)
+	}
+}
+
+func TestSII(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if query.Get("RUT") == "13117182" {
+			w.Write([]byte("Contribuyente presenta Inicio de Actividades: SI"))
+			return
+		}
+		http.Error(w, "mantención", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := &SII{
+		Client: srv.Client(),
+		URL:    srv.URL,
+		Params: func(ctx context.Context, r rut.Rut) (url.Values, error) {
+			return url.Values{"txt_code": {"1234"}}, nil
+		},
+	}
+	st, err := s.Verify(context.Background(), "13.117.182-k")
+	if err != nil || st != StatusActive {
+		t.Fatal("unexpected status", st, err)
+	}
+	if query.Get("DV") != "K" || query.Get("txt_code") != "1234" || query.Get("PRG") != "STC" {
+		t.Error("unexpected query", query)
+	}
+
+	if _, err := s.Verify(context.Background(), "11111111-1"); !errors.Is(err, ErrUnexpectedResponse) {
+		t.Error("expected ErrUnexpectedResponse, got", err)
+	}
+	if _, err := s.Verify(context.Background(), "12345678-0"); err != rut.ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
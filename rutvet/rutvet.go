@@ -0,0 +1,101 @@
+/*
+Package rutvet defines an Analyzer reporting the string literals holding a
+rut with a wrong 'digito verificador', typo'd fixtures mostly
+
+	$ go install github.com/alvarolm/rut/cmd/rutvet
+	$ go vet -vettool=$(which rutvet) ./...
+
+ruts are found anywhere in the literals, eg. `{"rut": "12.345.678-0"}`,
+spelled with 7 to 9 'cuerpo' digits, dotted or not. a comment containing
+"rutvet:ignore" on the line of the literal, or the line above, silences it
+for the intentionally invalid ones
+*/
+package rutvet
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+const ignoreDirective = "rutvet:ignore"
+
+var Analyzer = &analysis.Analyzer{
+	Name: "rutvet",
+	Doc:  "report string literals holding a rut with a wrong 'digito verificador'",
+	URL:  "https://pkg.go.dev/github.com/alvarolm/rut/rutvet",
+	Run:  run,
+}
+
+// shape matches the ruts not surrounded by other digits, dots, dashes or
+// letters, group 1 is the 'cuerpo' and group 2 the 'digito verificador'
+var shape = regexp.MustCompile(`(?:^|[^\w.-])(\d{1,3}\.\d{3}\.\d{3}|\d{7,9})-([\dkK])(?:$|[^\w.-])`)
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		ignored := ignoredLines(pass.Fset, file)
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			line := pass.Fset.Position(lit.Pos()).Line
+			if !ignored[line] && !ignored[line-1] {
+				check(pass, lit)
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// ignoredLines returns the lines with a comment holding the ignore directive
+func ignoredLines(fset *token.FileSet, file *ast.File) map[int]bool {
+	lines := make(map[int]bool)
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if strings.Contains(c.Text, ignoreDirective) {
+				lines[fset.Position(c.Slash).Line] = true
+			}
+		}
+	}
+	return lines
+}
+
+func check(pass *analysis.Pass, lit *ast.BasicLit) {
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return
+	}
+	// the offsets in s are the offsets in the source unless escapes moved them
+	exact := s == lit.Value[1:len(lit.Value)-1]
+
+	for _, m := range shape.FindAllStringSubmatchIndex(s, -1) {
+		body, dv := s[m[2]:m[3]], s[m[4]]
+		expected, ok := rutcore.DVString(strings.ReplaceAll(body, ".", ""))
+		if !ok || expected == dv || (dv == 'k' && expected == 'K') {
+			continue
+		}
+
+		d := analysis.Diagnostic{
+			Pos:     lit.Pos(),
+			End:     lit.End(),
+			Message: "rut " + s[m[2]:m[5]] + " has a wrong 'digito verificador', expected " + string(expected),
+		}
+		if exact {
+			pos := lit.Pos() + token.Pos(1+m[4])
+			d.Pos, d.End = lit.Pos()+token.Pos(1+m[2]), pos+1
+			d.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Replace the 'digito verificador' with " + string(expected),
+				TextEdits: []analysis.TextEdit{{Pos: pos, End: pos + 1, NewText: []byte{expected}}},
+			}}
+		}
+		pass.Report(d)
+	}
+}
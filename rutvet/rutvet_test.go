@@ -0,0 +1,13 @@
+package rutvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/alvarolm/rut/rutvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), rutvet.Analyzer, "a")
+}
@@ -0,0 +1,26 @@
+package a
+
+var (
+	valid  = "12345678-5"
+	dotted = "12.345.678-5"
+	lowerK = "13.117.182-k"
+
+	wrong       = "12345678-0"           // want `rut 12345678-0 has a wrong 'digito verificador', expected 5`
+	wrongDotted = "12.345.678-K"         // want `rut 12.345.678-K has a wrong 'digito verificador', expected 5`
+	inJSON      = `{"rut": "7654321-0"}` // want `rut 7654321-0 has a wrong 'digito verificador', expected 6`
+
+	ignored = "12345678-0" // rutvet:ignore
+
+	// rutvet:ignore
+	ignoredAbove = "12345678-0"
+
+	// not rut shaped
+	phone   = "+56 9 1234-5678"
+	date    = "2024-01-02"
+	serial  = "AB12345678-0"
+	suffix  = "12345678-01"
+	padded  = "12345678-5-0"
+	escaped = "\t12345678-5"
+)
+
+var escapedWrong = "\t12345678-0" // want `rut 12345678-0 has a wrong 'digito verificador', expected 5`
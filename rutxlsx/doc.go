@@ -0,0 +1,10 @@
+/*
+Package rutxlsx validates a 'Rol Único Tributario' column of an excel
+workbook and adds a report sheet with the invalid rows.
+
+it depends on github.com/xuri/excelize/v2, to keep it out of regular
+builds it's only compiled with the xlsx build tag:
+
+	go build -tags xlsx
+*/
+package rutxlsx
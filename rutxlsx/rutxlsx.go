@@ -0,0 +1,126 @@
+//go:build xlsx
+
+package rutxlsx
+
+import (
+	"errors"
+	"io"
+
+	"github.com/alvarolm/rut"
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	ErrHeaderNotFound = errors.New("rut column header not found")
+	ErrNoColumn       = rut.NewError("no_column", "row has no rut column")
+)
+
+// DefaultReportSheet is the Options.ReportSheet used when unset
+const DefaultReportSheet = "RUT inválidos"
+
+// Options selects the rut column of the workbook
+type Options struct {
+	// Sheet is the validated sheet, defaults to the first one
+	Sheet string
+
+	// Column is the zero based index of the rut column,
+	// ignored when Header is set
+	Column int
+
+	// Header selects the rut column by name,
+	// the first row is read as the header
+	Header string
+
+	// HasHeader marks the first row as a header when selecting by Column
+	HasHeader bool
+
+	// ReportSheet is the name of the added sheet, defaults to DefaultReportSheet
+	ReportSheet string
+}
+
+// Validate reads the workbook from r, validates the rut column and
+// writes the workbook to w with a report sheet listing every invalid row,
+// its value, the reason and the expected 'digito verificador'.
+// the report accounts every row but the header
+func Validate(w io.Writer, r io.Reader, opts Options) (report rut.Report, err error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	sheet := opts.Sheet
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	sheetname := opts.ReportSheet
+	if sheetname == "" {
+		sheetname = DefaultReportSheet
+	}
+
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if _, err = f.NewSheet(sheetname); err != nil {
+		return
+	}
+	if err = f.SetSheetRow(sheetname, "A1", &[]any{"Fila", "Valor", "Error", "DV esperado"}); err != nil {
+		return
+	}
+
+	column := opts.Column
+	for n := 1; rows.Next(); n++ {
+		var cells []string
+		if cells, err = rows.Columns(); err != nil {
+			return
+		}
+
+		if n == 1 && (opts.Header != "" || opts.HasHeader) {
+			if opts.Header != "" {
+				if column = indexOf(cells, opts.Header); column < 0 {
+					return report, ErrHeaderNotFound
+				}
+			}
+			continue
+		}
+
+		res := rut.Result{Err: ErrNoColumn}
+		if column >= 0 && column < len(cells) {
+			res = rut.Check(cells[column])
+		}
+		report.Add(res)
+		if res.Err == nil {
+			continue
+		}
+
+		var expected string
+		if res.ExpectedDV != 0 {
+			expected = string(res.ExpectedDV)
+		}
+
+		var cell string
+		if cell, err = excelize.CoordinatesToCellName(1, report.Invalid+1); err != nil {
+			return
+		}
+		if err = f.SetSheetRow(sheetname, cell, &[]any{n, res.Input, res.Err.Error(), expected}); err != nil {
+			return
+		}
+	}
+	if err = rows.Error(); err != nil {
+		return
+	}
+
+	return report, f.Write(w)
+}
+
+func indexOf(header []string, name string) int {
+	for i, h := range header {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
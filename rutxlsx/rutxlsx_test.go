@@ -0,0 +1,42 @@
+//go:build xlsx
+
+package rutxlsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestValidate(t *testing.T) {
+	in := excelize.NewFile()
+	in.SetSheetRow("Sheet1", "A1", &[]any{"nombre", "rut"})
+	in.SetSheetRow("Sheet1", "A2", &[]any{"uno", "11.111.111-1"})
+	in.SetSheetRow("Sheet1", "A3", &[]any{"dos", "12345678-0"})
+
+	var src, dst bytes.Buffer
+	if err := in.Write(&src); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Validate(&dst, &src, Options{Header: "rut"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Invalid != 1 {
+		t.Error("expected 1 invalid row, got", report.Invalid)
+	}
+
+	out, err := excelize.OpenReader(&dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := out.GetRows(DefaultReportSheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != "3" || rows[1][3] != "5" {
+		t.Error("unexpected report", rows)
+	}
+}
@@ -0,0 +1,64 @@
+package rut
+
+import "sync/atomic"
+
+// Next validates the rut and returns the one with the following 'cuerpo',
+// it fails with ErrMaxLength past the largest 'cuerpo' MaxRutlength allows
+func (r *Rut) Next() (Rut, error) {
+	body, err := r.Body()
+	if err != nil {
+		return "", err
+	}
+	return FromBody(body + 1)
+}
+
+// Prev validates the rut and returns the one with the preceding 'cuerpo',
+// it fails with ErrMinLength below the smallest 'cuerpo' MinRutlength allows
+func (r *Rut) Prev() (Rut, error) {
+	body, err := r.Body()
+	if err != nil {
+		return "", err
+	}
+	return FromBody(body - 1)
+}
+
+// Allocator hands out consecutive ruts, each one once,
+// it's safe for concurrent use
+type Allocator struct {
+	next, max int64
+}
+
+// NewAllocator returns an Allocator of the 'cuerpos' in [min, max),
+// both must satisfy the MinRutlength and MaxRutlength constraints
+func NewAllocator(min, max int) (*Allocator, error) {
+	if max <= min {
+		return nil, ErrInvalidRange
+	}
+	if _, err := FromBody(min); err != nil {
+		return nil, err
+	}
+	if _, err := FromBody(max - 1); err != nil {
+		return nil, err
+	}
+	return &Allocator{next: int64(min), max: int64(max)}, nil
+}
+
+// Next returns the following rut, it fails with ErrExhausted once every
+// 'cuerpo' in range was handed out
+func (a *Allocator) Next() (Rut, error) {
+	body := atomic.AddInt64(&a.next, 1) - 1
+	if body >= a.max {
+		// keeps next from overflowing however many calls follow
+		atomic.StoreInt64(&a.next, a.max)
+		return "", ErrExhausted
+	}
+	return fromBody(int(body)), nil
+}
+
+// Remaining returns how many ruts are left
+func (a *Allocator) Remaining() int {
+	if n := a.max - atomic.LoadInt64(&a.next); n > 0 {
+		return int(n)
+	}
+	return 0
+}
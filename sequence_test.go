@@ -0,0 +1,85 @@
+package rut
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNextPrev(t *testing.T) {
+	r := Rut("12.345.678-5")
+	next, err := r.Next()
+	if err != nil || next != "12345679-3" {
+		t.Error("unexpected next", next, err)
+	}
+	if prev, err := next.Prev(); err != nil || prev != "12345678-5" {
+		t.Error("unexpected prev", prev, err)
+	}
+
+	last := fromBody(99999999)
+	if _, err := last.Next(); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+	first := fromBody(1000000)
+	if _, err := first.Prev(); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+
+	invalid := Rut("12345678-0")
+	if _, err := invalid.Next(); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	const min, n = 20000000, 1000
+	a, err := NewAllocator(min, min+n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[Rut]bool)
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				r, err := a.Next()
+				if err == ErrExhausted {
+					return
+				}
+				mu.Lock()
+				if seen[r] {
+					t.Error("duplicated", r)
+				}
+				seen[r] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != n || a.Remaining() != 0 {
+		t.Error("expected", n, "ruts, got", len(seen), "remaining", a.Remaining())
+	}
+	for body := min; body < min+n; body++ {
+		if !seen[fromBody(body)] {
+			t.Fatal("missing", fromBody(body))
+		}
+	}
+}
+
+func TestNewAllocatorErrors(t *testing.T) {
+	if _, err := NewAllocator(10, 10); err != ErrInvalidRange {
+		t.Error("expected ErrInvalidRange, got", err)
+	}
+	if _, err := NewAllocator(10, 20000000); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+	if _, err := NewAllocator(20000000, 200000000); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+}
@@ -0,0 +1,57 @@
+package rut
+
+// RutSlice is a list of ruts with the usual operations matching them by
+// normalized value, '12.345.678-5' and '12345678-5' are the same rut.
+// every call validates the ruts involved, use rutset for large lists
+type RutSlice []Rut
+
+// IndexOf returns the index of the first rut of s equal to r, -1 when
+// there is none or r is invalid
+func (s RutSlice) IndexOf(r Rut) int {
+	key := r.Key()
+	if key == "" {
+		return -1
+	}
+	for i := range s {
+		if s[i].Key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether r is in s
+func (s RutSlice) Contains(r Rut) bool {
+	return s.IndexOf(r) >= 0
+}
+
+// Normalize rewrites every valid rut of s in its normalized form, the
+// invalid ones are left as is and the first validation error is returned
+func (s RutSlice) Normalize() (err error) {
+	for i := range s {
+		if _, verr := s[i].Validate(); verr != nil && err == nil {
+			err = verr
+		}
+	}
+	return
+}
+
+// Dedup removes in place the ruts equal to a previous one and returns the
+// shortened slice, the invalid ruts are compared as is
+func (s RutSlice) Dedup() RutSlice {
+	seen := make(map[string]bool, len(s))
+	out := s[:0]
+	for _, r := range s {
+		key := r.Key()
+		if key == "" {
+			key = "\x00" + string(r)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, r)
+	}
+	clear(s[len(out):])
+	return out
+}
@@ -0,0 +1,34 @@
+package rut
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRutSlice(t *testing.T) {
+	s := RutSlice{"12.345.678-5", "13117182-k", "12345678-0"}
+	if i := s.IndexOf("12345678-5"); i != 0 {
+		t.Error("expected 0, got", i)
+	}
+	if !s.Contains("13.117.182-K") || s.Contains("11111111-1") || s.Contains("12345678-0") {
+		t.Error("unexpected Contains")
+	}
+
+	if err := s.Normalize(); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if expected := (RutSlice{"12345678-5", "13117182-K", "12345678-0"}); !reflect.DeepEqual(s, expected) {
+		t.Error("expected", expected, "got", s)
+	}
+}
+
+func TestRutSliceDedup(t *testing.T) {
+	s := RutSlice{"12.345.678-5", "x", "12345678-5", "13.117.182-k", "x", "13117182-K"}
+	d := s.Dedup()
+	if expected := (RutSlice{"12.345.678-5", "x", "13.117.182-k"}); !reflect.DeepEqual(d, expected) {
+		t.Error("expected", expected, "got", d)
+	}
+	if s[2] != "13.117.182-k" || s[3] != "" || s[5] != "" {
+		t.Error("expected the tail to be cleared, got", s)
+	}
+}
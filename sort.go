@@ -0,0 +1,78 @@
+package rut
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownOrder = errors.New("unknown order, expected input, numeric or bytes")
+
+// Order selects how SortResults orders results
+type Order int
+
+const (
+	// OrderInput keeps the input order
+	OrderInput Order = iota
+
+	// OrderNumeric sorts by 'cuerpo', '9999999-3' before '10000000-8',
+	// the order people expect
+	OrderNumeric
+
+	// OrderBytes sorts by the bytes of the normalized form, '10000000-8'
+	// before '9999999-3', the order of sort(1) and join(1)
+	OrderBytes
+)
+
+var ordernames = [...]string{
+	OrderInput:   "input",
+	OrderNumeric: "numeric",
+	OrderBytes:   "bytes",
+}
+
+func (o Order) String() string {
+	if o < 0 || int(o) >= len(ordernames) {
+		return "Order(" + strconv.Itoa(int(o)) + ")"
+	}
+	return ordernames[o]
+}
+
+// ParseOrder parses the String form of an Order
+func ParseOrder(s string) (Order, error) {
+	for order, name := range ordernames {
+		if s == name {
+			return Order(order), nil
+		}
+	}
+	return OrderInput, ErrUnknownOrder
+}
+
+// Compare compares two results in the o order, the valid ones go first
+// and the invalid ones compare equal, keeping their relative order in a
+// stable sort
+func (o Order) Compare(a, b Result) int {
+	if o == OrderInput {
+		return 0
+	}
+	if av, bv := a.Valid(), b.Valid(); !av || !bv {
+		switch {
+		case av:
+			return -1
+		case bv:
+			return 1
+		}
+		return 0
+	}
+	if o == OrderNumeric {
+		return cmp.Compare(a.Rut.body(), b.Rut.body())
+	}
+	return strings.Compare(string(a.Rut), string(b.Rut))
+}
+
+// SortResults sorts results in the o order, the invalid ones are
+// moved last in their input order
+func SortResults(results []Result, o Order) {
+	slices.SortStableFunc(results, o.Compare)
+}
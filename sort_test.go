@@ -0,0 +1,36 @@
+package rut
+
+import "testing"
+
+func TestSortResults(t *testing.T) {
+	inputs := []string{"12.345.678-5", "1-9", "9.999.999-3", "12345678-0", "10000000-8"}
+	for _, tc := range []struct {
+		order    Order
+		expected []string
+	}{
+		{OrderInput, inputs},
+		{OrderNumeric, []string{"9.999.999-3", "10000000-8", "12.345.678-5", "1-9", "12345678-0"}},
+		{OrderBytes, []string{"10000000-8", "12.345.678-5", "9.999.999-3", "1-9", "12345678-0"}},
+	} {
+		results := make([]Result, len(inputs))
+		for i, in := range inputs {
+			results[i] = Check(in)
+		}
+		SortResults(results, tc.order)
+		for i, res := range results {
+			if res.Input != tc.expected[i] {
+				t.Error(tc.order, "expected", tc.expected, "got", res.Input, "at", i)
+				break
+			}
+		}
+	}
+
+	for _, o := range []Order{OrderInput, OrderNumeric, OrderBytes} {
+		if parsed, err := ParseOrder(o.String()); err != nil || parsed != o {
+			t.Error(o, "round trip failed", parsed, err)
+		}
+	}
+	if _, err := ParseOrder("random"); err != ErrUnknownOrder {
+		t.Error("expected ErrUnknownOrder, got", err)
+	}
+}
@@ -0,0 +1,78 @@
+package rut
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Stats describes the distribution of a dataset of ruts,
+// the zero value is ready to use and it marshals to JSON
+type Stats struct {
+	// Report has the totals, the failures by error Code and the duplicates
+	Report
+
+	// Persons and Companies classify the valid ruts by Kind
+	Persons   int `json:"persons"`
+	Companies int `json:"companies"`
+
+	// Millions counts the valid ruts by 'cuerpo' million,
+	// Millions[12] are the ones in 12.000.000-12.999.999
+	Millions map[int]int `json:"millions,omitempty"`
+}
+
+// Add accounts a result
+func (s *Stats) Add(res Result) {
+	s.Report.Add(res)
+	if res.Err != nil {
+		return
+	}
+
+	body := int(res.Rut.body())
+	if kindOf(body) == KindCompany {
+		s.Companies++
+	} else {
+		s.Persons++
+	}
+	if s.Millions == nil {
+		s.Millions = make(map[int]int)
+	}
+	s.Millions[body/1000000]++
+}
+
+// CompanyShare returns the fraction of the valid ruts that are companies
+func (s *Stats) CompanyShare() float64 {
+	if s.Valid == 0 {
+		return 0
+	}
+	return float64(s.Companies) / float64(s.Valid)
+}
+
+// InvalidRate returns the fraction of the inputs failing with each error Code
+func (s *Stats) InvalidRate() map[string]float64 {
+	rates := make(map[string]float64, len(s.Errors))
+	for code, n := range s.Errors {
+		rates[code] = float64(n) / float64(s.Total)
+	}
+	return rates
+}
+
+// MarshalJSON adds the company_share and invalid_rate ratios to the counts
+func (s *Stats) MarshalJSON() ([]byte, error) {
+	// stats drops the methods, keeping MarshalJSON from recursing
+	type stats Stats
+	return json.Marshal(struct {
+		*stats
+		CompanyShare float64            `json:"company_share"`
+		InvalidRate  map[string]float64 `json:"invalid_rate,omitempty"`
+	}{(*stats)(s), s.CompanyShare(), s.InvalidRate()})
+}
+
+// Analyze streams one rut per line from r into a Stats,
+// the lines are read as ValidateLines does
+func Analyze(r io.Reader) (stats *Stats, err error) {
+	stats = new(Stats)
+	err = ValidateLines(r, func(_ int, _ string, res Result) {
+		stats.Add(res)
+	})
+	return
+}
@@ -0,0 +1,54 @@
+package rut
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	in := "12.345.678-5\n12345678-5\n\n76086428-5\n12345678-0\n1-9\n13117182-K\n"
+	stats, err := Analyze(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Total != 6 || stats.Valid != 4 || stats.Invalid != 2 || stats.Duplicates != 1 {
+		t.Error("unexpected totals", stats.Report)
+	}
+	if stats.Persons != 3 || stats.Companies != 1 || stats.CompanyShare() != 0.25 {
+		t.Error("unexpected kinds", stats.Persons, stats.Companies)
+	}
+	if len(stats.Millions) != 3 || stats.Millions[12] != 2 || stats.Millions[13] != 1 || stats.Millions[76] != 1 {
+		t.Error("unexpected histogram", stats.Millions)
+	}
+	rates := stats.InvalidRate()
+	if len(rates) != 2 || rates["invalid_dv"] != 1.0/6 || rates["min_length"] != 1.0/6 {
+		t.Error("unexpected rates", rates)
+	}
+}
+
+func TestStatsJSON(t *testing.T) {
+	var stats Stats
+	for _, in := range []string{"12345678-5", "76086428-5", "12345678-0", "12345678-0"} {
+		stats.Add(Check(in))
+	}
+
+	b, err := json.Marshal(&stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["total"] != 4.0 || out["persons"] != 1.0 || out["company_share"] != 0.5 {
+		t.Error("unexpected json", string(b))
+	}
+	if rates, _ := out["invalid_rate"].(map[string]any); rates["invalid_dv"] != 0.5 {
+		t.Error("unexpected invalid_rate", string(b))
+	}
+	if millions, _ := out["millions"].(map[string]any); millions["12"] != 1.0 || millions["76"] != 1.0 {
+		t.Error("unexpected millions", string(b))
+	}
+}
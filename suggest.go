@@ -0,0 +1,101 @@
+package rut
+
+import "strings"
+
+// Suggestions returns up to max valid ruts at one edit of s, its likely
+// corrections, ranked by how common the mistake is:
+//
+//  1. two adjacent digits swapped, '12345687-5' -> '12345678-5'
+//  2. a wrong 'digito verificador'
+//  3. a wrong 'cuerpo' digit
+//  4. a 'cuerpo' digit missing or in excess
+//
+// nil is returned for valid ruts and inputs not looking like one
+func Suggestions(s string, max int) []Rut {
+	if max <= 0 || Check(s).Err == nil {
+		return nil
+	}
+
+	in := strings.ToUpper(strings.Replace(strings.TrimSpace(s), ".", "", -1))
+	var body, dv string
+	if i := strings.LastIndexByte(in, dvseparator); i >= 0 {
+		body, dv = in[:i], in[i+1:]
+	} else if len(in) > 1 {
+		body, dv = in[:len(in)-1], in[len(in)-1:]
+	}
+	if body == "" || len(dv) != 1 || strings.Trim(body, "0123456789") != "" || strings.Trim(dv, "0123456789K") != "" {
+		return nil
+	}
+
+	var (
+		out  []Rut
+		seen = make(map[Rut]bool)
+	)
+	add := func(body string, dv byte) bool {
+		if r, err := Parse(body + string(dvseparator) + string(dv)); err == nil && !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+		return len(out) < max
+	}
+	digits := []byte(body)
+
+	// adjacent transpositions, the last one swapping the 'digito verificador'
+	for i := 0; i+1 < len(digits); i++ {
+		d := append([]byte(nil), digits...)
+		d[i], d[i+1] = d[i+1], d[i]
+		if !add(string(d), dv[0]) {
+			return out
+		}
+	}
+	if dv[0] != 'K' {
+		d := append([]byte(nil), digits...)
+		last := d[len(d)-1]
+		d[len(d)-1] = dv[0]
+		if !add(string(d), last) {
+			return out
+		}
+	}
+
+	// 'digito verificador'
+	if !add(body, byte(computeDV(int(parseDigits(body))))) {
+		return out
+	}
+
+	// substitutions
+	for i := range digits {
+		for c := byte('0'); c <= '9'; c++ {
+			if c == digits[i] {
+				continue
+			}
+			d := append([]byte(nil), digits...)
+			d[i] = c
+			if !add(string(d), dv[0]) {
+				return out
+			}
+		}
+	}
+
+	// deletions and insertions
+	for i := range digits {
+		if !add(body[:i]+body[i+1:], dv[0]) {
+			return out
+		}
+	}
+	for i := 0; i <= len(digits); i++ {
+		for c := byte('0'); c <= '9'; c++ {
+			if !add(body[:i]+string(c)+body[i:], dv[0]) {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// parseDigits returns the value of a string of digits, it may overflow
+func parseDigits(s string) (n uint64) {
+	for i := 0; i < len(s); i++ {
+		n = n*10 + uint64(s[i]-'0')
+	}
+	return
+}
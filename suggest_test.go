@@ -0,0 +1,44 @@
+package rut
+
+import "testing"
+
+func TestSuggestions(t *testing.T) {
+	// transposed '7' and '8'
+	s := Suggestions("12.345.687-5", 3)
+	if len(s) != 3 || s[0] != "12345678-5" {
+		t.Error("unexpected suggestions", s)
+	}
+
+	// wrong 'digito verificador', no transposition validates
+	s = Suggestions("11111111-2", 5)
+	if len(s) == 0 || s[0] != "11111111-1" {
+		t.Error("unexpected suggestions", s)
+	}
+
+	// 'cuerpo' digit swapped with the 'digito verificador'
+	found := false
+	for _, r := range Suggestions("12345675-8", 10) {
+		found = found || r == "12345678-5"
+	}
+	if !found {
+		t.Error("expected 12345678-5 among the suggestions")
+	}
+
+	for _, r := range Suggestions("13117182-0", 20) {
+		if _, err := r.Validate(); err != nil {
+			t.Error("invalid suggestion", r, err)
+		}
+	}
+
+	if len(Suggestions("12345678-0", 1)) != 1 {
+		t.Error("expected one suggestion")
+	}
+	for _, in := range []string{"12345678-5", "foo", "", "1234a678-5", "12345678-X"} {
+		if s := Suggestions(in, 5); s != nil {
+			t.Error(in, "expected no suggestions, got", s)
+		}
+	}
+	if s := Suggestions("12345678-0", 0); s != nil {
+		t.Error("expected no suggestions, got", s)
+	}
+}
@@ -0,0 +1,90 @@
+package rut
+
+import (
+	"iter"
+	"maps"
+	"math/rand"
+	"slices"
+)
+
+// synthBucket is the 'cuerpo' range of Synthesize buckets
+const synthBucket = 1000000
+
+// Synthesize returns a synthetic dataset shaped as ruts: as many ruts as
+// the distinct valid ones in ruts, with the same count per 'cuerpo' million
+// (and so per Kind) and none of them in ruts, in random order. invalid ruts
+// are ignored. rnd defaults to the global math/rand source, a seeded one
+// reproduces the dataset. it fails with ErrExhausted when a million has
+// too few ruts left out of ruts
+func Synthesize(ruts iter.Seq[Rut], rnd *rand.Rand) ([]Rut, error) {
+	intn, shuffle := rand.Intn, rand.Shuffle
+	if rnd != nil {
+		intn, shuffle = rnd.Intn, rnd.Shuffle
+	}
+
+	seen := bodies(ruts)
+	counts := make(map[uint64]int)
+	for body := range seen.all() {
+		counts[body/synthBucket]++
+	}
+
+	lo, hi := bodyRange()
+	out := make([]Rut, 0, seen.len())
+	// in bucket order, a seeded rnd reproduces the output
+	for _, bucket := range slices.Sorted(maps.Keys(counts)) {
+		n := counts[bucket]
+		min, max := int(bucket)*synthBucket, int(bucket+1)*synthBucket
+		if min < lo {
+			min = lo
+		}
+		if max > hi+1 {
+			max = hi + 1
+		}
+		free := max - min - n
+		if n > free {
+			return nil, ErrExhausted
+		}
+
+		if 2*n > free {
+			// crowded bucket, picking out of the free 'cuerpos'
+			left := make([]int, 0, free)
+			for body := min; body < max; body++ {
+				if !seen.has(uint64(body)) {
+					left = append(left, body)
+				}
+			}
+			for i := 0; i < n; i++ {
+				j := i + intn(len(left)-i)
+				left[i], left[j] = left[j], left[i]
+				out = append(out, fromBody(left[i]))
+			}
+			continue
+		}
+
+		taken := make(map[int]bool, n)
+		for ; n > 0; n-- {
+			body := min + intn(max-min)
+			for seen.has(uint64(body)) || taken[body] {
+				body = min + intn(max-min)
+			}
+			taken[body] = true
+			out = append(out, fromBody(body))
+		}
+	}
+
+	shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out, nil
+}
+
+// bodyRange returns the smallest and largest 'cuerpos' satisfying the
+// MinRutlength and MaxRutlength constraints
+func bodyRange() (lo, hi int) {
+	lo, hi = 1, 9
+	for digits := 1; digits+2 < MinRutlength; digits++ {
+		lo *= 10
+	}
+	for digits := 1; digits+2 < MaxRutlength; digits++ {
+		hi = hi*10 + 9
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package rut
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSynthesize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var in []Rut
+	for i := 0; i < 1000; i++ {
+		body := 10000000 + rnd.Intn(5000000)
+		if i%4 == 0 {
+			body = 76000000 + rnd.Intn(1000000)
+		}
+		in = append(in, fromBody(body))
+	}
+	in = append(in, in[0], "12345678-0")
+
+	out, err := Synthesize(slices.Values(in), rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again, _ := Synthesize(slices.Values(in), rand.New(rand.NewSource(2))); !slices.Equal(out, again) {
+		t.Error("expected the same seed to reproduce the dataset")
+	}
+
+	var stats, synth Stats
+	for _, r := range in {
+		stats.Add(Check(string(r)))
+	}
+	for _, r := range out {
+		res := Check(string(r))
+		if res.Err != nil {
+			t.Fatal("invalid synthetic rut", r, res.Err)
+		}
+		if slices.Contains(in, r) {
+			t.Fatal("synthetic rut in the input", r)
+		}
+		synth.Add(res)
+	}
+
+	if synth.Total != stats.Valid-stats.Duplicates || synth.Duplicates != 0 {
+		t.Error("unexpected size", synth.Total, "expected", stats.Valid-stats.Duplicates)
+	}
+	if synth.Companies != stats.Companies-1 || synth.Persons != stats.Persons {
+		t.Error("unexpected kinds", synth.Persons, synth.Companies, "from", stats.Persons, stats.Companies)
+	}
+	for bucket, n := range synth.Millions {
+		if expected := stats.Millions[bucket]; n > expected {
+			t.Error("bucket", bucket, "expected at most", expected, "got", n)
+		}
+	}
+}
+
+func TestSynthesizeCrowded(t *testing.T) {
+	// half of the 1.000.000-1.999.999 range
+	var in []Rut
+	for body := 1000000; body < 1600000; body++ {
+		in = append(in, fromBody(body))
+	}
+	out, err := Synthesize(slices.Values(in[:500000]), nil)
+	if err != nil || len(out) != 500000 {
+		t.Fatal("unexpected", len(out), err)
+	}
+	if slices.ContainsFunc(out, func(r Rut) bool { return r.body() < 1500000 }) {
+		t.Error("synthetic rut in the input")
+	}
+
+	if _, err := Synthesize(slices.Values(in), nil); err != ErrExhausted {
+		t.Error("expected ErrExhausted, got", err)
+	}
+}
+
+func TestBodyRange(t *testing.T) {
+	if lo, hi := bodyRange(); lo != 1000000 || hi != 99999999 {
+		t.Error("unexpected range", lo, hi)
+	}
+}
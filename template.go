@@ -0,0 +1,74 @@
+package rut
+
+import "fmt"
+
+// TemplateFuncs returns the rutFormat, rutMask and rutValid template
+// functions, the map is accepted by the Funcs method of both
+// html/template and text/template
+//
+//	{{ rutFormat .Rut }}	12.345.678-5, invalid ruts are written unchanged
+//	{{ rutFormat .Rut "canonical" }}	any style accepted by ParseStyle
+//	{{ rutMask .Rut }}	**.***.678-5, invalid ruts are written as ""
+//	{{ if rutValid .Rut }}
+//
+// every function accepts a string, a Rut or a *Rut
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"rutFormat": templateFormat,
+		"rutMask":   templateMask,
+		"rutValid":  templateValid,
+	}
+}
+
+func templateString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case Rut:
+		return string(v)
+	case *Rut:
+		if v == nil {
+			return ""
+		}
+		return string(*v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func templateFormat(v any, style ...string) (string, error) {
+	input := templateString(v)
+	opts := FormatOptions{Style: StyleDotted}
+	if len(style) > 1 {
+		return "", fmt.Errorf("rutFormat: expected at most one style, got %d", len(style))
+	} else if len(style) == 1 {
+		var err error
+		if opts.Style, err = ParseStyle(style[0]); err != nil {
+			return "", fmt.Errorf("rutFormat: %w", err)
+		}
+	}
+
+	r := Rut(input)
+	f, err := r.Format(opts)
+	if err != nil {
+		return input, nil
+	}
+	return f, nil
+}
+
+func templateMask(v any) string {
+	r := Rut(templateString(v))
+	f, err := r.Format(FormatOptions{Style: StyleMasked})
+	if err != nil {
+		return ""
+	}
+	return f
+}
+
+func templateValid(v any) bool {
+	r := Rut(templateString(v))
+	_, err := r.Validate()
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package rut
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(
+		`{{ rutFormat .A }}|{{ rutFormat .B "canonical" }}|{{ rutMask .A }}|{{ rutMask .C }}|{{ rutFormat .C }}|{{ rutValid .A }}|{{ rutValid .C }}`))
+
+	r := Rut("13.117.182-k")
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]any{
+		"A": "12345678-5",
+		"B": &r,
+		"C": Rut("12345678-0"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "12.345.678-5|13117182-K|**.***.678-5||12345678-0|true|false"; b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestTemplateFuncsStyle(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(`{{ rutFormat . "nope" }}`))
+	if err := tmpl.Execute(&strings.Builder{}, "12345678-5"); err == nil {
+		t.Error("expected error for unknown style")
+	}
+}
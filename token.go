@@ -0,0 +1,79 @@
+package rut
+
+import (
+	"strings"
+
+	"github.com/alvarolm/rut/rutcore"
+)
+
+var ErrInvalidToken = NewError("invalid_token", "invalid rut token")
+
+// tokenalphabet is the base32 alphabet of RFC 4648, all of it is in the
+// QR code alphanumeric mode and needs no escaping in urls
+const tokenalphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// TokenLength is the length of every token
+const TokenLength = 7
+
+// the 35 bits of a token are the 'cuerpo' followed by the 4 bits of the
+// 'digito verificador' index in dvcodes, so mistyped tokens are likely
+// to decode to a wrong 'digito verificador' and be rejected
+const (
+	tokendvbits = 4
+	tokenmax    = 1<<(TokenLength*5-tokendvbits) - 1
+)
+
+// dvcodes are the 'digito verificador' symbols by their 4 bit code in the
+// tokens and the packed ruts, the order is part of both formats
+const dvcodes = rutcore.DVSymbols
+
+// EncodeToken validates the rut and returns its 7 character token,
+// eg. 'AF4MFHF' for '12345678-5'. DecodeToken reverses it
+func (r *Rut) EncodeToken() (string, error) {
+	body, err := r.Body()
+	if err != nil {
+		return "", err
+	}
+	if body > tokenmax {
+		return "", ErrMaxLength
+	}
+
+	dv := strings.IndexByte(dvcodes, string(*r)[len(*r)-1])
+	v := uint64(body)<<tokendvbits | uint64(dv)
+	var token [TokenLength]byte
+	for i := TokenLength - 1; i >= 0; i-- {
+		token[i] = tokenalphabet[v&31]
+		v >>= 5
+	}
+	return string(token[:]), nil
+}
+
+// DecodeToken returns the rut of a token, it's case insensitive
+func DecodeToken(token string) (Rut, error) {
+	if len(token) != TokenLength {
+		return "", ErrInvalidToken
+	}
+
+	var v uint64
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		d := strings.IndexByte(tokenalphabet, c)
+		if d < 0 {
+			return "", ErrInvalidToken
+		}
+		v = v<<5 | uint64(d)
+	}
+
+	body, dv := int(v>>tokendvbits), int(v&(1<<tokendvbits-1))
+	rut, err := FromBody(body)
+	if err != nil {
+		return "", err
+	}
+	if dv >= len(dvcodes) || rune(dvcodes[dv]) != computeDV(body) {
+		return "", ErrInvalidToken
+	}
+	return rut, nil
+}
@@ -0,0 +1,77 @@
+package rut
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToken(t *testing.T) {
+	for body := 1000000; body < 100000000; body += 9973 {
+		r := fromBody(body)
+		token, err := r.EncodeToken()
+		if err != nil {
+			t.Fatal(r, err)
+		}
+		if len(token) != TokenLength || strings.Trim(token, tokenalphabet) != "" {
+			t.Fatal(r, "unexpected token", token)
+		}
+		for _, tk := range []string{token, strings.ToLower(token)} {
+			if decoded, err := DecodeToken(tk); err != nil || decoded != r {
+				t.Fatal(tk, "expected", r, "got", decoded, err)
+			}
+		}
+	}
+
+	r := Rut("12.345.678-5")
+	if token, _ := r.EncodeToken(); token != "AF4MFHF" {
+		t.Error("unexpected token", token)
+	}
+}
+
+func TestTokenErrors(t *testing.T) {
+	r := Rut("12345678-0")
+	if _, err := r.EncodeToken(); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+
+	for _, token := range []string{"", "AF4MFH", "AF4MFHFA", "AF4MFH1", "AF4MFH-"} {
+		if _, err := DecodeToken(token); err != ErrInvalidToken {
+			t.Error(token, "expected ErrInvalidToken, got", err)
+		}
+	}
+
+	// a changed character yields a wrong 'digito verificador' most of the time
+	var rejected int
+	for _, c := range tokenalphabet {
+		if c == 'F' {
+			continue
+		}
+		if _, err := DecodeToken("AF4MFH" + string(c)); err != nil {
+			rejected++
+		}
+	}
+	if rejected < 25 {
+		t.Error("expected most typos to be rejected, got", rejected)
+	}
+
+	if _, err := DecodeToken("AAAAAAB"); err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", err)
+	}
+}
+
+func TestTokenNineDigitBodies(t *testing.T) {
+	r := Rut("123.456.789-2")
+	if _, err := r.EncodeToken(); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+
+	defer func(max int) { MaxRutlength = max }(MaxRutlength)
+	MaxRutlength = NineDigitRutlength
+	token, err := r.EncodeToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, err := DecodeToken(token); err != nil || decoded != "123456789-2" {
+		t.Error("unexpected rut", decoded, err)
+	}
+}
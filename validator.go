@@ -0,0 +1,144 @@
+package rut
+
+import (
+	"strings"
+	"unicode"
+)
+
+var ErrBelowMinBody = NewError("below_min_body", "'cuerpo' below the plausible minimum")
+
+// Validator validates ruts like Rut.Validate with optional hooks,
+// the zero value is ready to use and it's safe for concurrent use as
+// long as its hooks are
+type Validator struct {
+	// OnInvalid, if set, is called with every input failing validation,
+	// meant for central logging, counting or sampling
+	OnInvalid func(input string, err error)
+
+	// MinBody, if set, rejects the valid ruts with a lower 'cuerpo' as
+	// ErrBelowMinBody, eg. 1000000 catches the short data entry errors
+	// like '123-6' whose 'digito verificador' happens to match
+	MinBody int
+
+	// NineDigitBodies accepts the 'cuerpos' up to 999.999.999 whatever
+	// MaxRutlength is set to
+	NineDigitBodies bool
+
+	// Policy constrains the valid ruts further, eg. PolicyPerson. MinBody
+	// and NineDigitBodies apply on top of it
+	Policy Policy
+
+	// PreserveDVCase keeps a lowercase 'k' as 'digito verificador' in
+	// the normalized rut instead of rewriting it to 'K'
+	PreserveDVCase bool
+
+	// TrimSpace ignores the whitespace around the input, eg. the
+	// ' 12.345.678-5\n' of a copy-paste, use InspectTrimmed to tell
+	// what was stripped. a Policy with MachineFormat still rejects it,
+	// the policy checks the input as given
+	TrimSpace bool
+
+	// DetailedErrors returns ErrExpectedDigit as an *InputError telling
+	// the position of the offending character, errors.Is still matches it
+	DetailedErrors bool
+
+	// EchoInput returns every error as an *InputError carrying the shape
+	// of the input, its digits as '9' and its letters as 'a', sanitized and
+	// truncated, see MaxEchoLength, so log aggregation can group the
+	// failures by input without logging ruts or risking log injection
+	EchoInput bool
+
+	// EchoRawInput is EchoInput echoing the input itself instead of its
+	// shape, the logs get the ruts of the failures
+	EchoRawInput bool
+
+	// Separators, if set, are the characters accepted as 'digito
+	// verificador' separator besides '-', eg. "/ " for '12345678/5' and
+	// '12345678 5'. the normalized rut always has a '-'
+	Separators string
+
+	// Rules are applied in order to the ruts passing the other checks,
+	// the first one broken fails the rut with a *RuleError
+	Rules []Rule
+}
+
+// Validate validates input and returns its normalized form
+func (v *Validator) Validate(input string) (Rut, error) {
+	res := v.Check(input)
+	return res.Rut, res.Err
+}
+
+// Check validates input and reports the outcome as a Result
+func (v *Validator) Check(input string) (res Result) {
+	minLen, maxLen := v.Policy.lengths()
+	if v.NineDigitBodies && maxLen < NineDigitRutlength {
+		maxLen = NineDigitRutlength
+	}
+
+	trimmed := input
+	if v.TrimSpace {
+		trimmed = strings.TrimSpace(input)
+	}
+
+	res = check(withDash(trimmed, v.Separators), minLen, maxLen)
+	res.Input = input
+	if res.Err == nil {
+		if int(res.Rut.body()) < v.MinBody {
+			res.Rut, res.Err = "", ErrBelowMinBody
+		} else if err := v.Policy.check(input, res.Rut); err != nil {
+			res.Rut, res.Err = "", err
+		} else if err := v.rules(res.Rut); err != nil {
+			res.Rut, res.Err = "", err
+		} else if v.PreserveDVCase && strings.HasSuffix(trimmed, "k") {
+			res.Rut = Rut(lowerDV(string(res.Rut)))
+		}
+	}
+	if v.DetailedErrors && res.Err == ErrExpectedDigit {
+		var offset int
+		if v.TrimSpace {
+			offset = len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+		}
+		res.Err = expectedDigitError(trimmed, offset)
+	}
+	if (v.EchoInput || v.EchoRawInput) && res.Err != nil {
+		ie, ok := res.Err.(*InputError)
+		if !ok {
+			ie = &InputError{Err: res.Err, Index: -1}
+		}
+		ie.Input = echo(input, v.EchoRawInput)
+		res.Err = ie
+	}
+	if res.Err != nil && v.OnInvalid != nil {
+		v.OnInvalid(input, res.Err)
+	}
+	return
+}
+
+// rules returns the *RuleError of the first rule r breaks
+func (v *Validator) rules(r Rut) error {
+	for _, rule := range v.Rules {
+		if err := rule.Check(r); err != nil {
+			return &RuleError{Rule: rule.Name(), Err: err}
+		}
+	}
+	return nil
+}
+
+// withDash returns s with its 'digito verificador' separator rewritten to
+// '-' when it's one of seps
+func withDash(s, seps string) string {
+	if seps == "" {
+		return s
+	}
+	// the separator is the second to last character other than '.'
+	i, kept := len(s), 0
+	for i > 0 && kept < 2 {
+		if i--; s[i] != '.' {
+			kept++
+		}
+	}
+	if kept < 2 || s[i] == dvseparator || strings.IndexByte(seps, s[i]) < 0 {
+		return s
+	}
+	return s[:i] + string(dvseparator) + s[i+1:]
+}
@@ -0,0 +1,166 @@
+package rut
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestValidatorOnInvalid(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		failed = map[string]error{}
+	)
+	v := &Validator{OnInvalid: func(input string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed[input] = err
+	}}
+
+	if r, err := v.Validate("11.111.111-1"); err != nil || r != "11111111-1" {
+		t.Error("unexpected result", r, err)
+	}
+	if _, err := v.Validate("12345678-0"); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if res := v.Check("1"); res.Err != ErrMinLength {
+		t.Error("expected ErrMinLength, got", res.Err)
+	}
+
+	if len(failed) != 2 || failed["12345678-0"] != ErrinvalidDV || failed["1"] != ErrMinLength {
+		t.Error("unexpected hook calls", failed)
+	}
+}
+
+func TestValidatorZero(t *testing.T) {
+	var v Validator
+	if _, err := v.Validate("12345678-0"); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
+
+func TestValidatorMinBody(t *testing.T) {
+	defer func(min int) { MinRutlength = min }(MinRutlength)
+	MinRutlength = 3
+
+	var v Validator
+	if r, err := v.Validate("123-6"); err != nil || r != "123-6" {
+		t.Error("unexpected result", r, err)
+	}
+
+	v.MinBody = 1000000
+	if res := v.Check("123-6"); res.Err != ErrBelowMinBody || res.Rut != "" || res.ExpectedDV != '6' {
+		t.Error("expected ErrBelowMinBody, got", res)
+	}
+	if _, err := v.Validate("1.000.000-k"); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if r, err := v.Validate("1.000.000-9"); err != nil || r != "1000000-9" {
+		t.Error("unexpected result", r, err)
+	}
+}
+
+func TestValidatorNineDigitBodies(t *testing.T) {
+	var v Validator
+	if _, err := v.Validate("123.456.789-2"); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+
+	v.NineDigitBodies = true
+	r, err := v.Validate("123.456.789-2")
+	if err != nil || r != "123456789-2" {
+		t.Fatal("unexpected result", r, err)
+	}
+	if _, err := v.Validate("123.456.789-3"); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+	if _, err := v.Validate("1.234.567.890-1"); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+	if r, err := v.Validate("123456789-2"); err != nil || r.grouped(".", false) != "123.456.789-2" || r.MaskedFormat() != "***.***.789-2" {
+		t.Error("unexpected formatting", r.grouped(".", false), r.MaskedFormat(), err)
+	}
+
+	// rendered whatever MaxRutlength is
+	if s, err := r.Format(FormatOptions{Style: StyleDotted}); err != nil || s != "123.456.789-2" {
+		t.Error("unexpected format", s, err)
+	}
+	if s, err := r.DecimalFormatSafe(); err != nil || s != "123.456.789-2" {
+		t.Error("unexpected format", s, err)
+	}
+	if f, err := r.AllFormats(); err != nil || f.Masked != "***.***.789-2" {
+		t.Error("unexpected formats", f, err)
+	}
+
+	// Body keeps to MaxRutlength as FromBody does
+	if _, err := r.Body(); err != ErrMaxLength {
+		t.Error("expected ErrMaxLength, got", err)
+	}
+	defer func(max int) { MaxRutlength = max }(MaxRutlength)
+	MaxRutlength = NineDigitRutlength
+	if body, err := r.Body(); err != nil || body != 123456789 {
+		t.Error("unexpected body", body, err)
+	}
+	if k, err := r.Kind(); err != nil || k != KindCompany {
+		t.Error("unexpected kind", k, err)
+	}
+}
+
+func TestValidatorPreserveDVCase(t *testing.T) {
+	v := Validator{PreserveDVCase: true}
+	if r, err := v.Validate("13.117.182-k"); err != nil || r != "13117182-k" {
+		t.Error("unexpected result", r, err)
+	}
+	if r, err := v.Validate("13.117.182-K"); err != nil || r != "13117182-K" {
+		t.Error("unexpected result", r, err)
+	}
+	if _, err := v.Validate("12345678-k"); err != ErrinvalidDV {
+		t.Error("expected ErrinvalidDV, got", err)
+	}
+}
+
+func TestValidatorTrimSpace(t *testing.T) {
+	var v Validator
+	if _, err := v.Validate(" 12.345.678-5\n"); err == nil {
+		t.Error("expected the whitespace to fail")
+	}
+
+	v.TrimSpace = true
+	res := v.Check(" 12.345.678-5\n")
+	if res.Err != nil || res.Rut != "12345678-5" || res.Input != " 12.345.678-5\n" {
+		t.Error("unexpected result", res)
+	}
+
+	v.Policy = PolicyMachine
+	if _, err := v.Validate(" 12345678-5 "); err != ErrNotMachine {
+		t.Error("expected ErrNotMachine, got", err)
+	}
+}
+
+func TestValidatorSeparators(t *testing.T) {
+	var v Validator
+	if _, err := v.Validate("12345678/5"); err != ErrNoDVSeparator {
+		t.Error("expected ErrNoDVSeparator, got", err)
+	}
+
+	v.Separators = "/ "
+	for _, input := range []string{"12345678/5", "12.345.678 5", "12345678-5", "12.345.678/5."} {
+		if r, err := v.Validate(input); err != nil || r != "12345678-5" {
+			t.Error(input, "unexpected result", r, err)
+		}
+	}
+	for input, expected := range map[string]error{
+		"12345678_5": ErrNoDVSeparator,
+		"12345678/0": ErrinvalidDV,
+		"1234/678/5": ErrExpectedDigit,
+		"/5":         ErrMinLength,
+	} {
+		if _, err := v.Validate(input); err != expected {
+			t.Error(input, "expected", expected, "got", err)
+		}
+	}
+
+	v.Policy = PolicyMachine
+	if _, err := v.Validate("12345678/5"); err != ErrNotMachine {
+		t.Error("expected ErrNotMachine, got", err)
+	}
+}
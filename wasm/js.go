@@ -0,0 +1,57 @@
+//go:build js && wasm
+
+package wasm
+
+import "syscall/js"
+
+// Register sets the rut object with validate, format and generate on global
+func Register(global js.Value) {
+	obj := js.Global().Get("Object").New()
+	obj.Set("validate", js.FuncOf(func(this js.Value, args []js.Value) any {
+		return Validate(arg(args, 0).String())
+	}))
+	obj.Set("format", js.FuncOf(func(this js.Value, args []js.Value) any {
+		style := ""
+		if a := arg(args, 1); a.Type() == js.TypeString {
+			style = a.String()
+		}
+		return Format(arg(args, 0).String(), style)
+	}))
+	obj.Set("generate", js.FuncOf(func(this js.Value, args []js.Value) any {
+		n := 1
+		if a := arg(args, 0); a.Type() == js.TypeNumber {
+			n = a.Int()
+		}
+		var opts GenerateOptions
+		if o := arg(args, 1); o.Type() == js.TypeObject {
+			opts.Kind = str(o.Get("kind"))
+			opts.Style = str(o.Get("style"))
+			opts.Min = num(o.Get("min"))
+			opts.Max = num(o.Get("max"))
+			opts.Unique = o.Get("unique").Truthy()
+		}
+		return Generate(n, opts)
+	}))
+	global.Set("rut", obj)
+}
+
+func arg(args []js.Value, i int) js.Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return js.ValueOf("")
+}
+
+func str(v js.Value) string {
+	if v.Type() == js.TypeString {
+		return v.String()
+	}
+	return ""
+}
+
+func num(v js.Value) int {
+	if v.Type() == js.TypeNumber {
+		return v.Int()
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+/*
+Package wasm exposes validate, format and generate to JavaScript, so
+browsers run the same 'digito verificador' logic as the servers
+
+	GOOS=js GOARCH=wasm go build -o rut.wasm github.com/alvarolm/rut/cmd/rutwasm
+
+after loading rut.wasm with wasm_exec.js the global rut object provides
+
+	rut.validate("11.111.111-1") // {input, valid, normalized, expectedDV, code, error}
+	rut.format("11111111-1", "dotted") // {formatted, code, error}
+	rut.generate(10, {kind: "person", style: "dotted", unique: true}) // {ruts, code, error}
+
+every function returns a plain object, failures set code and error
+instead of throwing
+*/
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/alvarolm/rut"
+)
+
+// MaxGenerate bounds the n of generate
+const MaxGenerate = 100000
+
+// Object is the result of every exported function, it has the shape
+// accepted by js.ValueOf
+type Object = map[string]any
+
+func failure(err error) Object {
+	return Object{"code": rut.Code(err), "error": err.Error()}
+}
+
+// Validate is rut.validate
+func Validate(input string) Object {
+	res := rut.Check(input)
+	obj := Object{"input": input, "valid": res.Valid()}
+	if res.Valid() {
+		obj["normalized"] = string(res.Rut)
+	}
+	if res.ExpectedDV != 0 {
+		obj["expectedDV"] = string(res.ExpectedDV)
+	}
+	if res.Err != nil {
+		obj["code"] = rut.Code(res.Err)
+		obj["error"] = res.Err.Error()
+	}
+	return obj
+}
+
+// Format is rut.format, style defaults to dotted
+func Format(input, style string) Object {
+	opts := rut.FormatOptions{Style: rut.StyleDotted}
+	if style != "" {
+		var err error
+		if opts.Style, err = rut.ParseStyle(style); err != nil {
+			return failure(err)
+		}
+	}
+	r := rut.Rut(input)
+	f, err := r.Format(opts)
+	if err != nil {
+		return failure(err)
+	}
+	return Object{"formatted": f}
+}
+
+// GenerateOptions are the options of rut.generate
+type GenerateOptions struct {
+	Kind, Style string
+	Min, Max    int
+	Unique      bool
+}
+
+// Generate is rut.generate
+func Generate(n int, opts GenerateOptions) Object {
+	if n < 1 || n > MaxGenerate {
+		return failure(rut.NewError("invalid_n", fmt.Sprintf("n must be between 1 and %d", MaxGenerate)))
+	}
+	gopts := rut.GenerateOptions{Min: opts.Min, Max: opts.Max, Unique: opts.Unique}
+	style := rut.StyleDotted
+	var err error
+	if opts.Kind != "" {
+		if gopts.Kind, err = rut.ParseKind(opts.Kind); err != nil {
+			return failure(err)
+		}
+	}
+	if opts.Style != "" {
+		if style, err = rut.ParseStyle(opts.Style); err != nil {
+			return failure(err)
+		}
+	}
+
+	g, err := rut.NewGenerator(gopts)
+	if err != nil {
+		return failure(err)
+	}
+	ruts := make([]any, n)
+	for i := range ruts {
+		r, err := g.Next()
+		if err != nil {
+			return failure(err)
+		}
+		if ruts[i], err = r.Format(rut.FormatOptions{Style: style}); err != nil {
+			return failure(err)
+		}
+	}
+	return Object{"ruts": ruts}
+}
@@ -0,0 +1,51 @@
+package wasm
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	obj := Validate("11.111.111-1")
+	if obj["valid"] != true || obj["normalized"] != "11111111-1" {
+		t.Error("unexpected result", obj)
+	}
+
+	obj = Validate("12345678-0")
+	if obj["valid"] != false || obj["code"] != "invalid_dv" || obj["expectedDV"] != "5" {
+		t.Error("unexpected result", obj)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if obj := Format("11111111-1", ""); obj["formatted"] != "11.111.111-1" {
+		t.Error("unexpected result", obj)
+	}
+	if obj := Format("11111111-1", "masked"); obj["formatted"] != "**.***.111-1" {
+		t.Error("unexpected result", obj)
+	}
+	if obj := Format("12345678-0", ""); obj["code"] != "invalid_dv" {
+		t.Error("unexpected result", obj)
+	}
+	if obj := Format("11111111-1", "nope"); obj["error"] == nil {
+		t.Error("expected error for unknown style")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	obj := Generate(5, GenerateOptions{Kind: "company", Style: "canonical", Unique: true})
+	ruts, ok := obj["ruts"].([]any)
+	if !ok || len(ruts) != 5 {
+		t.Fatal("unexpected result", obj)
+	}
+	for _, r := range ruts {
+		if obj := Validate(r.(string)); obj["valid"] != true {
+			t.Error("generated invalid rut", r)
+		}
+	}
+
+	for _, n := range []int{0, MaxGenerate + 1} {
+		if obj := Generate(n, GenerateOptions{}); obj["code"] != "invalid_n" {
+			t.Error(n, "unexpected result", obj)
+		}
+	}
+}